@@ -5,6 +5,6 @@ go 1.23.1
 require go.uber.org/zap v1.27.0
 
 require (
-	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/multierr v1.11.0
 	gopkg.in/yaml.v2 v2.4.0
 )
@@ -64,6 +64,7 @@ func ShutDown() {
 	stop.Wait()
 	shutdown.Wait()
 	log.Debug("Shutdown initiated")
+	_ = logger.Close() // Flush buffered log entries before exiting.
 	os.Exit(0)
 }
 
@@ -76,6 +77,7 @@ func ShutDownSlowly(delay time.Duration) {
 	shutdown.Wait()
 	log.Debug("ShutDownSlowly initiated")
 	time.Sleep(delay)
+	_ = logger.Close() // Flush buffered log entries before exiting.
 	os.Exit(0)
 }
 
@@ -0,0 +1,305 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// Async overflow policies
+const (
+	OverflowBlock          OverflowPolicy = "block"
+	OverflowDropNewest     OverflowPolicy = "drop_newest"
+	OverflowDropOldest     OverflowPolicy = "drop_oldest"
+	OverflowDropBelowLevel OverflowPolicy = "drop_below_level"
+)
+
+type OverflowPolicy string
+
+type AsyncConfig struct {
+	// BufferSize is the number of entries the ring buffer holds (default: 1024).
+	BufferSize int
+
+	// FlushInterval is the maximum time an entry waits in the buffer before it is written (default: 1s).
+	FlushInterval time.Duration
+
+	// Overflow decides what happens when the buffer is full (default: block, accepts: OverflowBlock,
+	// OverflowDropNewest, OverflowDropOldest, OverflowDropBelowLevel).
+	Overflow OverflowPolicy
+
+	// DropBelowLevel is the level below which entries are dropped when the buffer is full and
+	// Overflow is OverflowDropBelowLevel; entries at or above it block (default: warn).
+	DropBelowLevel LevelType
+}
+
+// AsyncStats reports the counters of an asynchronous sink.
+type AsyncStats struct {
+	// Sink identifies the sink by its output.
+	Sink string
+
+	// Buffered is the number of entries waiting to be written.
+	Buffered int
+
+	// Written is the number of entries written to the sink.
+	Written uint64
+
+	// Dropped is the number of entries discarded by the overflow policy.
+	Dropped uint64
+}
+
+// asyncEntry is an entry waiting in the ring buffer, along with the core it must be written to.
+type asyncEntry struct {
+	core   zapcore.Core
+	ent    zapcore.Entry
+	fields []zapcore.Field
+}
+
+// asyncQueue is the bounded ring buffer shared by an asynchronous core and all its children.
+// A single worker drains it into the wrapped cores.
+type asyncQueue struct {
+	sink      string
+	policy    OverflowPolicy
+	dropBelow zapcore.Level
+	interval  time.Duration
+
+	mu       sync.Mutex
+	notFull  *sync.Cond
+	drained  *sync.Cond
+	entries  []asyncEntry
+	head     int
+	count    int
+	writing  bool
+	closed   bool
+	wake     chan struct{}
+	done     chan struct{}
+	written  atomic.Uint64
+	dropped  atomic.Uint64
+	syncCore zapcore.Core
+}
+
+// newAsyncQueue creates the ring buffer of an asynchronous sink and starts its worker.
+//
+// Parameters:
+// - sink: The name identifying the sink in AsyncStats.
+// - config: A pointer to an AsyncConfig struct containing the buffer settings.
+// - core: The zapcore.Core the entries are eventually written to.
+//
+// Returns:
+// - A pointer to the running asyncQueue.
+// - An error if the overflow policy or drop level is unknown.
+func newAsyncQueue(sink string, config *AsyncConfig, core zapcore.Core) (*asyncQueue, error) {
+	policy := utils.DefaultIfEmpty(config.Overflow, OverflowBlock)
+	switch policy {
+	case OverflowBlock, OverflowDropNewest, OverflowDropOldest, OverflowDropBelowLevel:
+	default:
+		return nil, fmt.Errorf("unknown async overflow policy %q", policy)
+	}
+
+	dropBelow, err := ParseLevel(string(utils.DefaultIfEmpty(config.DropBelowLevel, LevelWarn)))
+	if err != nil {
+		return nil, err
+	}
+
+	q := &asyncQueue{
+		sink:      sink,
+		policy:    policy,
+		dropBelow: logLevelMap[dropBelow],
+		interval:  utils.DefaultIfEmpty(config.FlushInterval, time.Second),
+		entries:   make([]asyncEntry, utils.DefaultIfEmpty(config.BufferSize, 1024)),
+		wake:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		syncCore:  core,
+	}
+	q.notFull = sync.NewCond(&q.mu)
+	q.drained = sync.NewCond(&q.mu)
+
+	go q.run()
+	return q, nil
+}
+
+// push adds an entry to the buffer, applying the overflow policy when it is full.
+func (q *asyncQueue) push(item asyncEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.count == len(q.entries) && !q.closed {
+		switch {
+		case q.policy == OverflowDropNewest,
+			q.policy == OverflowDropBelowLevel && item.ent.Level < q.dropBelow:
+			q.drop(item.ent)
+			return
+		case q.policy == OverflowDropOldest:
+			q.drop(q.entries[q.head].ent)
+			q.entries[q.head] = asyncEntry{}
+			q.head = (q.head + 1) % len(q.entries)
+			q.count--
+		default:
+			q.signal()
+			q.notFull.Wait()
+		}
+	}
+
+	if q.closed {
+		// The worker is gone; write synchronously so late entries are not lost.
+		q.mu.Unlock()
+		_ = item.core.Write(item.ent, item.fields)
+		q.mu.Lock()
+		return
+	}
+
+	q.entries[(q.head+q.count)%len(q.entries)] = item
+	q.count++
+	if q.count >= len(q.entries)/2 {
+		q.signal()
+	}
+}
+
+// drop counts a discarded entry. The caller must hold q.mu.
+func (q *asyncQueue) drop(zapcore.Entry) {
+	q.dropped.Add(1)
+}
+
+// signal wakes the worker without blocking.
+func (q *asyncQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run drains the buffer whenever it fills up, on every flush interval and when a flush is requested.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.wake:
+		case <-ticker.C:
+		}
+
+		if closed := q.drain(); closed {
+			return
+		}
+	}
+}
+
+// drain writes all buffered entries and reports whether the queue has been closed.
+func (q *asyncQueue) drain() bool {
+	q.mu.Lock()
+	batch := make([]asyncEntry, 0, q.count)
+	for q.count > 0 {
+		batch = append(batch, q.entries[q.head])
+		q.entries[q.head] = asyncEntry{}
+		q.head = (q.head + 1) % len(q.entries)
+		q.count--
+	}
+	q.writing = true
+	q.notFull.Broadcast()
+	q.mu.Unlock()
+
+	for _, item := range batch {
+		_ = item.core.Write(item.ent, item.fields)
+		q.written.Add(1)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.writing = false
+	q.drained.Broadcast()
+	return q.closed && q.count == 0
+}
+
+// flush blocks until every entry buffered before the call has been written.
+func (q *asyncQueue) flush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for (q.count > 0 || q.writing) && !q.isStopped() {
+		q.signal()
+		q.drained.Wait()
+	}
+}
+
+// isStopped reports whether the worker has exited.
+func (q *asyncQueue) isStopped() bool {
+	select {
+	case <-q.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// close flushes the buffer and stops the worker.
+func (q *asyncQueue) close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.notFull.Broadcast()
+	q.mu.Unlock()
+
+	q.signal()
+	<-q.done
+	return q.syncCore.Sync()
+}
+
+// stats returns the current counters of the queue.
+func (q *asyncQueue) stats() AsyncStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return AsyncStats{Sink: q.sink, Buffered: q.count, Written: q.written.Load(), Dropped: q.dropped.Load()}
+}
+
+// asyncCore is a zapcore.Core that queues entries in a ring buffer and writes them to the
+// wrapped core from a background worker. Field values are written after the call returns,
+// so values that are mutated after logging may be observed in their new state.
+type asyncCore struct {
+	zapcore.Core
+	queue *asyncQueue
+}
+
+// newAsyncCore wraps core so that entries are written asynchronously through queue.
+func newAsyncCore(core zapcore.Core, queue *asyncQueue) zapcore.Core {
+	return &asyncCore{Core: core, queue: queue}
+}
+
+// With implements zapcore.Core.
+func (c *asyncCore) With(fields []zapcore.Field) zapcore.Core {
+	return &asyncCore{Core: c.Core.With(fields), queue: c.queue}
+}
+
+// Check implements zapcore.Core.
+func (c *asyncCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core. Entries that terminate the process are written synchronously
+// after the buffer has been flushed, so they are never lost.
+func (c *asyncCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level > zapcore.ErrorLevel {
+		c.queue.flush()
+		return c.Core.Write(ent, fields)
+	}
+
+	c.queue.push(asyncEntry{core: c.Core, ent: ent, fields: append([]zapcore.Field(nil), fields...)})
+	return nil
+}
+
+// Sync implements zapcore.Core. It flushes the buffer before syncing the wrapped core.
+func (c *asyncCore) Sync() error {
+	c.queue.flush()
+	return c.Core.Sync()
+}
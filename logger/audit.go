@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audit outcomes
+const (
+	OutcomeSuccess AuditOutcome = "success"
+	OutcomeFailure AuditOutcome = "failure"
+	OutcomeDenied  AuditOutcome = "denied"
+)
+
+// auditGenesisHash is the previous hash of the first record of an audit file.
+var auditGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+type AuditOutcome string
+
+// AuditEvent is an administrative action to record in the audit log.
+type AuditEvent struct {
+	// Actor identifies who performed the action, such as a user or service account.
+	Actor string
+
+	// Action names what was done, such as "user.delete".
+	Action string
+
+	// Resource identifies what the action was performed on.
+	Resource string
+
+	// Outcome tells whether the action succeeded (accepts: OutcomeSuccess, OutcomeFailure, OutcomeDenied).
+	Outcome AuditOutcome
+
+	// Time is when the action happened (default: the time it is logged).
+	Time time.Time
+}
+
+// AuditRecord is a line of the audit file: an event chained to the previous record by its hash.
+type AuditRecord struct {
+	Seq      uint64       `json:"seq"`
+	Time     time.Time    `json:"time"`
+	Actor    string       `json:"actor"`
+	Action   string       `json:"action"`
+	Resource string       `json:"resource"`
+	Outcome  AuditOutcome `json:"outcome"`
+	PrevHash string       `json:"prev_hash"`
+	Hash     string       `json:"hash"`
+}
+
+type AuditConfig struct {
+	// Path is the audit file. Records are appended, so an existing file continues its chain.
+	Path string
+
+	// Key enables an HMAC-SHA256 chain, so that the file cannot be rewritten consistently without
+	// the key (default: a plain SHA-256 hash chain).
+	Key []byte
+}
+
+// AuditLogger writes audit events to a dedicated file, separately from application logs.
+// Each record carries the hash of the previous one, so that deleting, reordering or modifying
+// records breaks the chain and is detected by VerifyAudit.
+type AuditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+	key  []byte
+	seq  uint64
+	prev string
+}
+
+// NewAuditLogger opens the audit file described by config, resuming the chain from its last record.
+//
+// Parameters:
+// - config: An AuditConfig struct containing the file path and chain key.
+//
+// Returns:
+// - A pointer to the initialized AuditLogger.
+// - An error if the file cannot be opened or its last record cannot be read.
+func NewAuditLogger(config AuditConfig) (*AuditLogger, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("audit path is required")
+	}
+
+	file, err := os.OpenFile(config.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file: %w", err)
+	}
+
+	audit := &AuditLogger{file: file, key: config.Key, prev: auditGenesisHash}
+	last, err := lastAuditRecord(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if last != nil {
+		audit.seq, audit.prev = last.Seq, last.Hash
+	}
+
+	return audit, nil
+}
+
+// Log appends event to the audit file and syncs it to disk.
+func (a *AuditLogger) Log(event AuditEvent) error {
+	if event.Actor == "" || event.Action == "" || event.Outcome == "" {
+		return fmt.Errorf("audit event requires an actor, an action and an outcome")
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	record := AuditRecord{
+		Seq:      a.seq + 1,
+		Time:     event.Time.UTC(),
+		Actor:    event.Actor,
+		Action:   event.Action,
+		Resource: event.Resource,
+		Outcome:  event.Outcome,
+		PrevHash: a.prev,
+	}
+	record.Hash = auditHash(a.key, record)
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if err := a.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit file: %w", err)
+	}
+
+	a.seq, a.prev = record.Seq, record.Hash
+	return nil
+}
+
+// Head returns the sequence number and hash of the last record. Storing them outside the
+// audit file allows VerifyAudit callers to detect truncation of the latest records.
+func (a *AuditLogger) Head() (uint64, string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.seq, a.prev
+}
+
+// Close closes the audit file.
+func (a *AuditLogger) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.file.Close()
+}
+
+// AuditVerification is the result of a successful VerifyAudit.
+type AuditVerification struct {
+	// Records is the number of verified records.
+	Records uint64
+
+	// HeadHash is the hash of the last record.
+	HeadHash string
+}
+
+// VerifyAudit checks the chain of an audit file. It detects deleted records through gaps in
+// the sequence numbers, reordered records through broken previous-hash links, and modified
+// records through hash mismatches.
+//
+// Parameters:
+// - r: The content of the audit file.
+// - key: The HMAC key the file was written with, or nil for a plain hash chain.
+//
+// Returns:
+// - The number of records and the hash of the last one.
+// - An error describing the first inconsistency and its line number.
+func VerifyAudit(r io.Reader, key []byte) (AuditVerification, error) {
+	result := AuditVerification{HeadHash: auditGenesisHash}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return result, fmt.Errorf("line %d: malformed record: %w", line, err)
+		}
+		switch {
+		case record.Seq <= result.Records:
+			return result, fmt.Errorf("line %d: sequence %d out of order after %d (reordered or duplicated records)", line, record.Seq, result.Records)
+		case record.Seq != result.Records+1:
+			return result, fmt.Errorf("line %d: sequence jumps from %d to %d (records deleted or reordered)", line, result.Records, record.Seq)
+		case record.PrevHash != result.HeadHash:
+			return result, fmt.Errorf("line %d: previous hash does not match record %d (chain broken)", line, result.Records)
+		case !hmac.Equal([]byte(record.Hash), []byte(auditHash(key, record))):
+			return result, fmt.Errorf("line %d: hash mismatch for record %d (record modified or wrong key)", line, record.Seq)
+		}
+
+		result.Records, result.HeadHash = record.Seq, record.Hash
+	}
+
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read audit file: %w", err)
+	}
+	return result, nil
+}
+
+// auditHash computes the chained hash of record, excluding its own hash.
+func auditHash(key []byte, record AuditRecord) string {
+	var h hash.Hash
+	if len(key) > 0 {
+		h = hmac.New(sha256.New, key)
+	} else {
+		h = sha256.New()
+	}
+
+	record.Hash = ""
+	payload, _ := json.Marshal(record)
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lastAuditRecord returns the last record of the audit file, or nil when it is empty.
+func lastAuditRecord(file *os.File) (*AuditRecord, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var last []byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit file: %w", err)
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	var record AuditRecord
+	if err := json.Unmarshal(last, &record); err != nil {
+		return nil, fmt.Errorf("failed to resume audit chain: %w", err)
+	}
+	return &record, nil
+}
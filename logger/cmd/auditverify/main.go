@@ -0,0 +1,60 @@
+// Command auditverify checks the hash chain of an audit file written by logger.AuditLogger.
+//
+// Usage:
+//
+//	auditverify -file audit.log [-key-env AUDIT_KEY] [-head <hash>]
+//
+// The HMAC key is read from the environment variable named by -key-env, hex encoded, so that
+// it does not appear in the process list. When -head is given, the hash of the last record must
+// match it, which detects the deletion of the latest records.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-metaverse/zeri/logger"
+)
+
+func main() {
+	path := flag.String("file", "", "path of the audit file")
+	keyEnv := flag.String("key-env", "", "environment variable holding the hex encoded HMAC key")
+	head := flag.String("head", "", "expected hash of the last record")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var key []byte
+	if *keyEnv != "" {
+		decoded, err := hex.DecodeString(os.Getenv(*keyEnv))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid key in %s: %v\n", *keyEnv, err)
+			os.Exit(2)
+		}
+		key = decoded
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	defer file.Close()
+
+	result, err := logger.VerifyAudit(file, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "FAIL: %v\n", err)
+		os.Exit(1)
+	}
+	if *head != "" && *head != result.HeadHash {
+		fmt.Fprintf(os.Stderr, "FAIL: last record hash %s does not match expected head %s (records deleted from the end)\n", result.HeadHash, *head)
+		os.Exit(1)
+	}
+
+	fmt.Printf("OK: %d records verified, head %s\n", result.Records, result.HeadHash)
+}
@@ -10,9 +10,47 @@ type Config struct {
 	// EnableDevMode indicates whether the logger should operate in development mode with more verbose output.
 	EnableDevMode bool
 
-	// Level defines the logging level (e.g., debug, info, warn, error).
+	// Level defines the logging level (e.g., debug, info, warn, error, dpanic, panic, fatal).
+	// Names are case-insensitive; unknown names are rejected.
 	Level LevelType
 
-	// Encoding specifies the format for log output (e.g., json, console).
+	// Encoding specifies the format for log output (e.g., json, console, logfmt, pretty).
 	Encoding EncodingType
+
+	// Layout controls key names, timestamp, caller and level formats, optionally from a backend preset
+	// (default: message/time/level/log keys, RFC3339 local timestamps, full caller, capital levels).
+	Layout LayoutConfig
+
+	// Sinks lists the outputs of the logger, each with its own level, encoding and key names
+	// (default: a single stderr sink using Level and Encoding).
+	Sinks []SinkConfig
+
+	// StructuredErrors logs every error field as a structured object with its type, context and
+	// cause chain, like ErrorField (default: false).
+	StructuredErrors bool
+
+	// Sampling controls how repeated entries with the same level and message are sampled
+	// (default: 100 per second, then every 100th in production mode; disabled in development mode).
+	Sampling *SamplingConfig
+
+	// Dedup collapses identical entries logged within a window into a repeat summary (default: disabled).
+	Dedup *DedupConfig
+
+	// Recent keeps the latest entries of each level in memory, served by RecentHandler (default: disabled).
+	Recent *RecentConfig
+
+	// Hooks are callbacks run asynchronously for the entries at or above their level (default: none).
+	Hooks []Hook
+
+	// TraceIDKey is the field name used for the trace identifier taken from the context (default: trace_id).
+	TraceIDKey string
+
+	// SpanIDKey is the field name used for the span identifier taken from the context (default: span_id).
+	SpanIDKey string
+
+	// Redact enables masking of sensitive values by key name, pattern and struct tag (default: disabled).
+	Redact *RedactConfig
+
+	// EnableSlog makes the logger the log/slog default and redirects the standard log package to it.
+	EnableSlog bool
 }
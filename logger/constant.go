@@ -7,22 +7,37 @@ import (
 
 // Log level
 const (
-	LevelInfo  LevelType = "info"
-	LevelWarn  LevelType = "warn"
-	LevelDebug LevelType = "debug"
-	LevelError LevelType = "error"
+	LevelInfo   LevelType = "info"
+	LevelWarn   LevelType = "warn"
+	LevelDebug  LevelType = "debug"
+	LevelError  LevelType = "error"
+	LevelDPanic LevelType = "dpanic"
+	LevelPanic  LevelType = "panic"
+	LevelFatal  LevelType = "fatal"
 )
 
 // Log encoding
 const (
 	EncodingJSON    EncodingType = "json"
 	EncodingConsole EncodingType = "console"
+	EncodingLogfmt  EncodingType = "logfmt"
+	EncodingPretty  EncodingType = "pretty"
 )
 
 // Log level map
 var logLevelMap = map[LevelType]zapcore.Level{
-	LevelDebug: zapcore.DebugLevel,
-	LevelInfo:  zap.InfoLevel,
-	LevelWarn:  zapcore.WarnLevel,
-	LevelError: zapcore.ErrorLevel,
+	LevelDebug:  zapcore.DebugLevel,
+	LevelInfo:   zap.InfoLevel,
+	LevelWarn:   zapcore.WarnLevel,
+	LevelError:  zapcore.ErrorLevel,
+	LevelDPanic: zapcore.DPanicLevel,
+	LevelPanic:  zapcore.PanicLevel,
+	LevelFatal:  zapcore.FatalLevel,
+}
+
+// Log level aliases accepted by ParseLevel
+var logLevelAliases = map[string]LevelType{
+	"warning":  LevelWarn,
+	"err":      LevelError,
+	"critical": LevelDPanic,
 }
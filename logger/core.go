@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// writeThrough runs the entry through the checks of core, such as level filtering and sampling,
+// and writes the fields to every core that accepts it.
+func writeThrough(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+// resources tracks what a logger owns besides its cores, such as open outputs and the
+// workers of asynchronous sinks, so they can be released when the logger is closed.
+type resources struct {
+	mu       sync.Mutex
+	closers  []func() error
+	queues   []*asyncQueue
+	sampling samplingCounters
+	recent   *recentBuffer
+	hooks    *hookSet
+}
+
+// addCloser registers a function releasing a resource when the logger is closed.
+func (r *resources) addCloser(closer func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closers = append(r.closers, closer)
+}
+
+// close releases the resources in reverse order of registration.
+func (r *resources) close() error {
+	r.mu.Lock()
+	closers := r.closers
+	r.closers = nil
+	r.mu.Unlock()
+
+	var err error
+	for i := len(closers) - 1; i >= 0; i-- {
+		err = multierr.Append(err, closers[i]())
+	}
+	return err
+}
+
+// asyncStats returns the counters of the asynchronous sinks.
+func (r *resources) asyncStats() []AsyncStats {
+	r.mu.Lock()
+	queues := r.queues
+	r.mu.Unlock()
+
+	stats := make([]AsyncStats, 0, len(queues))
+	for _, queue := range queues {
+		stats = append(stats, queue.stats())
+	}
+	return stats
+}
+
+// hookStats returns the counters of the hooks.
+func (r *resources) hookStats() []HookStats {
+	if r.hooks == nil {
+		return nil
+	}
+	return r.hooks.stats()
+}
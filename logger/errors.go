@@ -0,0 +1,361 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// errorMaxDepth bounds how far cause chains are walked.
+const errorMaxDepth = 16
+
+// ContextError is implemented by errors carrying key-value context to include in logs.
+type ContextError interface {
+	error
+	ErrorContext() map[string]any
+}
+
+// StackError is implemented by errors carrying the stack trace captured when they were created.
+type StackError interface {
+	error
+	StackTrace() string
+}
+
+// ErrorField returns a field named "error" describing err as a structured object: its message
+// and type, the key-value context attached with ErrorWithContext, and the chain of causes found
+// through Unwrap, with joined errors expanded. When err carries a captured stack trace, the
+// stack is written in the stacktrace key of the entry.
+//
+// Usage example:
+//
+//	logger.ZeriLogger.Desugar().Error("failed to save order", logger.ErrorField(err))
+func ErrorField(err error) zap.Field {
+	return NamedErrorField("error", err)
+}
+
+// NamedErrorField is like ErrorField with a custom field name.
+func NamedErrorField(key string, err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Object(key, errorObject{err: err})
+}
+
+// ErrorWithContext attaches key-value context to err. The returned error has the same message
+// and unwraps to err.
+//
+// Parameters:
+// - err: The error to annotate.
+// - keysAndValues: Alternating keys and values, as accepted by SugaredLogger.Infow.
+//
+// Returns:
+// - The annotated error, or nil if err is nil.
+func ErrorWithContext(err error, keysAndValues ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	context := make(map[string]any, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		context[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	return &contextError{err: err, context: context}
+}
+
+// ErrorWithStack attaches the current stack trace to err, unless an error in its chain already
+// carries one. The returned error has the same message and unwraps to err.
+func ErrorWithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var stackErr StackError
+	if errors.As(err, &stackErr) {
+		return err
+	}
+	return &stackError{err: err, stack: captureStack(2)}
+}
+
+// errorStack returns the stack trace carried by err or one of its causes.
+func errorStack(err error) string {
+	var stackErr StackError
+	if errors.As(err, &stackErr) {
+		return stackErr.StackTrace()
+	}
+	return ""
+}
+
+// contextError is an error annotated with key-value context.
+type contextError struct {
+	err     error
+	context map[string]any
+}
+
+// Error implements error.
+func (e *contextError) Error() string { return e.err.Error() }
+
+// Unwrap returns the annotated error.
+func (e *contextError) Unwrap() error { return e.err }
+
+// ErrorContext implements ContextError.
+func (e *contextError) ErrorContext() map[string]any { return e.context }
+
+// stackError is an error annotated with the stack trace of its creation.
+type stackError struct {
+	err   error
+	stack string
+}
+
+// Error implements error.
+func (e *stackError) Error() string { return e.err.Error() }
+
+// Unwrap returns the annotated error.
+func (e *stackError) Unwrap() error { return e.err }
+
+// StackTrace implements StackError.
+func (e *stackError) StackTrace() string { return e.stack }
+
+// captureStack formats the stack of the caller, skipping the given number of frames, in the
+// format zap uses for stack traces.
+func captureStack(skip int) string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+1, pcs)])
+
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		fmt.Fprintf(&sb, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// errorObject marshals an error with its type, context and causes.
+type errorObject struct {
+	err   error
+	depth int
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o errorObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	err, context := peelAnnotations(o.err)
+	if err := addErrorLink(enc, err, context, o.depth); err != nil {
+		return err
+	}
+	if _, ok := err.(interface{ Unwrap() []error }); ok {
+		return nil
+	}
+
+	var causes []error
+	for cause := errors.Unwrap(err); cause != nil && len(causes) < errorMaxDepth; cause = errors.Unwrap(cause) {
+		causes = append(causes, cause)
+		if _, ok := cause.(interface{ Unwrap() []error }); ok {
+			break
+		}
+	}
+	if len(causes) > 0 {
+		return enc.AddArray("causes", causeArray{causes: causes, depth: o.depth + 1})
+	}
+	return nil
+}
+
+// causeArray marshals the links of an Unwrap chain. A joined error ends the chain and is
+// expanded in place.
+type causeArray struct {
+	causes []error
+	depth  int
+}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (a causeArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	var context map[string]any
+	for _, cause := range a.causes {
+		if isAnnotation(cause) {
+			context = mergeErrorContext(context, cause)
+			continue
+		}
+
+		link, linkContext := cause, mergeErrorContext(context, cause)
+		context = nil
+		err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(obj zapcore.ObjectEncoder) error {
+			return addErrorLink(obj, link, linkContext, a.depth)
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errorArray marshals the errors of an errors.Join result.
+type errorArray struct {
+	errs  []error
+	depth int
+}
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (a errorArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, err := range a.errs {
+		if err == nil {
+			continue
+		}
+		if a.depth >= errorMaxDepth {
+			enc.AppendString(err.Error())
+			continue
+		}
+		if e := enc.AppendObject(errorObject{err: err, depth: a.depth}); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// addErrorLink adds the message, type and context of a single error, expanding joined errors.
+func addErrorLink(enc zapcore.ObjectEncoder, err error, context map[string]any, depth int) error {
+	enc.AddString("message", err.Error())
+	enc.AddString("type", fmt.Sprintf("%T", err))
+
+	if len(context) > 0 {
+		if e := enc.AddObject("context", zapcore.ObjectMarshalerFunc(func(obj zapcore.ObjectEncoder) error {
+			keys := make([]string, 0, len(context))
+			for key := range context {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				zap.Any(key, context[key]).AddTo(obj)
+			}
+			return nil
+		})); e != nil {
+			return e
+		}
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok && depth < errorMaxDepth {
+		return enc.AddArray("errors", errorArray{errs: joined.Unwrap(), depth: depth + 1})
+	}
+	return nil
+}
+
+// peelAnnotations skips the wrappers added by ErrorWithContext and ErrorWithStack, returning
+// the annotated error along with the collected context.
+func peelAnnotations(err error) (error, map[string]any) {
+	var context map[string]any
+	for isAnnotation(err) {
+		context = mergeErrorContext(context, err)
+		err = errors.Unwrap(err)
+	}
+	return err, mergeErrorContext(context, err)
+}
+
+// isAnnotation reports whether err only annotates its cause without changing its meaning.
+func isAnnotation(err error) bool {
+	switch err.(type) {
+	case *contextError, *stackError:
+		return true
+	}
+	return false
+}
+
+// mergeErrorContext adds the context carried by err to context.
+func mergeErrorContext(context map[string]any, err error) map[string]any {
+	contextErr, ok := err.(ContextError)
+	if !ok {
+		return context
+	}
+
+	merged := make(map[string]any, len(context)+len(contextErr.ErrorContext()))
+	for key, value := range context {
+		merged[key] = value
+	}
+	for key, value := range contextErr.ErrorContext() {
+		merged[key] = value
+	}
+	return merged
+}
+
+// errorCore is a zapcore.Core that writes the stack trace carried by a logged error in the
+// stacktrace key of the entry and, when enabled, structures every error field like ErrorField.
+type errorCore struct {
+	zapcore.Core
+	structured bool
+}
+
+// newErrorCore wraps core with error handling.
+func newErrorCore(core zapcore.Core, structured bool) zapcore.Core {
+	return &errorCore{Core: core, structured: structured}
+}
+
+// With implements zapcore.Core.
+func (c *errorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorCore{Core: c.Core.With(c.structure(fields)), structured: c.structured}
+}
+
+// Check implements zapcore.Core.
+func (c *errorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *errorCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	for _, field := range fields {
+		if err := fieldError(field); err != nil {
+			if stack := errorStack(err); stack != "" {
+				ent.Stack = stack
+				break
+			}
+		}
+	}
+	return writeThrough(c.Core, ent, c.structure(fields))
+}
+
+// structure converts plain error fields into structured ones when enabled.
+func (c *errorCore) structure(fields []zapcore.Field) []zapcore.Field {
+	if !c.structured {
+		return fields
+	}
+
+	var structured []zapcore.Field
+	for i, field := range fields {
+		if field.Type != zapcore.ErrorType {
+			continue
+		}
+		if err, ok := field.Interface.(error); ok && err != nil {
+			if structured == nil {
+				structured = append([]zapcore.Field(nil), fields...)
+			}
+			structured[i] = NamedErrorField(field.Key, err)
+		}
+	}
+	if structured == nil {
+		return fields
+	}
+	return structured
+}
+
+// fieldError returns the error held by an error field, or nil.
+func fieldError(field zapcore.Field) error {
+	switch field.Type {
+	case zapcore.ErrorType:
+		err, _ := field.Interface.(error)
+		return err
+	case zapcore.ObjectMarshalerType:
+		if obj, ok := field.Interface.(errorObject); ok {
+			return obj.err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/go-metaverse/zeri/logger"
 )
 
@@ -13,11 +15,14 @@ func main() {
 		// Enables development mode (default: false, accepts: bool)
 		EnableDevMode: false,
 		// Sets log level (default: Info; defaults to Debug if EnableDevMode is true;
-		// accepts: logger.LevelInfo, logger.LevelWarn, logger.LevelError, logger.LevelDebug)
+		// accepts: logger.LevelDebug, logger.LevelInfo, logger.LevelWarn, logger.LevelError,
+		// logger.LevelDPanic, logger.LevelPanic, logger.LevelFatal)
 		Level: logger.LevelDebug,
 		// Sets log output format (default: JSON; defaults to CONSOLE if EnableDevMode is true;
-		// accepts: logger.EncodingConsole, logger.EncodingJSON)
+		// accepts: logger.EncodingConsole, logger.EncodingJSON, logger.EncodingLogfmt, logger.EncodingPretty)
 		Encoding: logger.EncodingConsole,
+		// Masks sensitive values such as passwords, tokens and emails (default: disabled)
+		Redact: &logger.RedactConfig{Keys: []string{"api_key"}},
 	})
 
 	defer func() {
@@ -36,4 +41,8 @@ func main() {
 	log.Info("Info message...")
 	log.Warn("Warn message...")
 	log.Error("Error message...")
+
+	// Log with the trace and span identifiers carried by the context.
+	ctx := logger.ContextWithTrace(context.Background(), logger.NewTraceContext())
+	logger.WithContext(ctx).Info("Info message with trace...")
 }
@@ -0,0 +1,360 @@
+package logger
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// flatField is a field whose nested objects have been flattened into a key path.
+type flatField struct {
+	path  []string
+	value string
+}
+
+// flatEncoder is a zapcore.ObjectEncoder that records fields in order as key paths and
+// formatted values. It backs the logfmt and pretty encoders, which only differ in rendering.
+type flatEncoder struct {
+	config *zapcore.EncoderConfig
+	fields []flatField
+	prefix []string
+}
+
+// newFlatEncoder creates an empty flatEncoder using the given encoder settings.
+func newFlatEncoder(config *zapcore.EncoderConfig) *flatEncoder {
+	return &flatEncoder{config: config}
+}
+
+// clone returns a copy of the encoder that can be extended independently.
+func (e *flatEncoder) clone() *flatEncoder {
+	return &flatEncoder{
+		config: e.config,
+		fields: append([]flatField(nil), e.fields...),
+		prefix: append([]string(nil), e.prefix...),
+	}
+}
+
+// add records key with its formatted value under the current prefix.
+func (e *flatEncoder) add(key, value string) {
+	path := make([]string, 0, len(e.prefix)+1)
+	e.fields = append(e.fields, flatField{path: append(append(path, e.prefix...), key), value: value})
+}
+
+// nested runs fn with key pushed onto the prefix.
+func (e *flatEncoder) nested(key string, fn func() error) error {
+	e.prefix = append(e.prefix, key)
+	defer func() { e.prefix = e.prefix[:len(e.prefix)-1] }()
+	return fn()
+}
+
+// AddArray implements zapcore.ObjectEncoder. Arrays of primitives are written as a single
+// bracketed value; objects inside arrays are flattened under their index.
+func (e *flatEncoder) AddArray(key string, marshaler zapcore.ArrayMarshaler) error {
+	arr := &flatArrayEncoder{enc: e, key: key}
+	if err := marshaler.MarshalLogArray(arr); err != nil {
+		return err
+	}
+	if !arr.hasObjects {
+		e.add(key, "["+strings.Join(arr.items, ", ")+"]")
+	}
+	return nil
+}
+
+// AddObject implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddObject(key string, marshaler zapcore.ObjectMarshaler) error {
+	return e.nested(key, func() error { return marshaler.MarshalLogObject(e) })
+}
+
+// AddBinary implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddBinary(key string, value []byte) {
+	e.add(key, base64.StdEncoding.EncodeToString(value))
+}
+
+// AddByteString implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddByteString(key string, value []byte) { e.add(key, string(value)) }
+
+// AddBool implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddBool(key string, value bool) { e.add(key, strconv.FormatBool(value)) }
+
+// AddComplex128 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddComplex128(key string, value complex128) {
+	e.add(key, strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+// AddComplex64 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddComplex64(key string, value complex64) {
+	e.add(key, strconv.FormatComplex(complex128(value), 'g', -1, 64))
+}
+
+// AddDuration implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddDuration(key string, value time.Duration) {
+	e.add(key, e.primitive(func(arr zapcore.PrimitiveArrayEncoder) {
+		if e.config.EncodeDuration != nil {
+			e.config.EncodeDuration(value, arr)
+			return
+		}
+		arr.AppendString(value.String())
+	}))
+}
+
+// AddFloat64 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddFloat64(key string, value float64) {
+	e.add(key, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// AddFloat32 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddFloat32(key string, value float32) {
+	e.add(key, strconv.FormatFloat(float64(value), 'g', -1, 32))
+}
+
+// AddInt implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddInt(key string, value int) { e.AddInt64(key, int64(value)) }
+
+// AddInt64 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddInt64(key string, value int64) { e.add(key, strconv.FormatInt(value, 10)) }
+
+// AddInt32 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddInt32(key string, value int32) { e.AddInt64(key, int64(value)) }
+
+// AddInt16 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddInt16(key string, value int16) { e.AddInt64(key, int64(value)) }
+
+// AddInt8 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddInt8(key string, value int8) { e.AddInt64(key, int64(value)) }
+
+// AddString implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddString(key, value string) { e.add(key, value) }
+
+// AddTime implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddTime(key string, value time.Time) {
+	e.add(key, e.primitive(func(arr zapcore.PrimitiveArrayEncoder) {
+		if e.config.EncodeTime != nil {
+			e.config.EncodeTime(value, arr)
+			return
+		}
+		arr.AppendString(value.Format(time.RFC3339Nano))
+	}))
+}
+
+// AddUint implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddUint(key string, value uint) { e.AddUint64(key, uint64(value)) }
+
+// AddUint64 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddUint64(key string, value uint64) { e.add(key, strconv.FormatUint(value, 10)) }
+
+// AddUint32 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddUint32(key string, value uint32) { e.AddUint64(key, uint64(value)) }
+
+// AddUint16 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddUint16(key string, value uint16) { e.AddUint64(key, uint64(value)) }
+
+// AddUint8 implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddUint8(key string, value uint8) { e.AddUint64(key, uint64(value)) }
+
+// AddUintptr implements zapcore.ObjectEncoder.
+func (e *flatEncoder) AddUintptr(key string, value uintptr) { e.AddUint64(key, uint64(value)) }
+
+// AddReflected implements zapcore.ObjectEncoder. The value is converted through its JSON form
+// so that maps and structs are flattened like objects.
+func (e *flatEncoder) AddReflected(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var decoded any
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	e.addDecoded(key, decoded)
+	return nil
+}
+
+// addDecoded records a value decoded from JSON, flattening maps and arrays of objects.
+func (e *flatEncoder) addDecoded(key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		_ = e.nested(key, func() error {
+			for _, k := range keys {
+				e.addDecoded(k, v[k])
+			}
+			return nil
+		})
+	case []any:
+		if !hasNested(v) {
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			e.add(key, "["+strings.Join(items, ", ")+"]")
+			return
+		}
+		_ = e.nested(key, func() error {
+			for i, item := range v {
+				e.addDecoded(strconv.Itoa(i), item)
+			}
+			return nil
+		})
+	case nil:
+		e.add(key, "null")
+	default:
+		e.add(key, fmt.Sprint(v))
+	}
+}
+
+// hasNested reports whether items contains maps or arrays.
+func hasNested(items []any) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]any, []any:
+			return true
+		}
+	}
+	return false
+}
+
+// OpenNamespace implements zapcore.ObjectEncoder. All following fields are nested under key.
+func (e *flatEncoder) OpenNamespace(key string) {
+	e.prefix = append(e.prefix, key)
+}
+
+// primitive captures the single value appended by fn, such as a time or level encoder.
+func (e *flatEncoder) primitive(fn func(zapcore.PrimitiveArrayEncoder)) string {
+	arr := &flatArrayEncoder{enc: e}
+	fn(arr)
+	return strings.Join(arr.items, " ")
+}
+
+// flatArrayEncoder collects the elements of an array as formatted values.
+type flatArrayEncoder struct {
+	enc        *flatEncoder
+	key        string
+	items      []string
+	hasObjects bool
+}
+
+// AppendArray implements zapcore.ArrayEncoder.
+func (a *flatArrayEncoder) AppendArray(marshaler zapcore.ArrayMarshaler) error {
+	nested := &flatArrayEncoder{enc: a.enc, key: a.key + "." + strconv.Itoa(len(a.items))}
+	if err := marshaler.MarshalLogArray(nested); err != nil {
+		return err
+	}
+	a.items = append(a.items, "["+strings.Join(nested.items, ", ")+"]")
+	return nil
+}
+
+// AppendObject implements zapcore.ArrayEncoder.
+func (a *flatArrayEncoder) AppendObject(marshaler zapcore.ObjectMarshaler) error {
+	a.hasObjects = true
+	index := strconv.Itoa(len(a.items))
+	a.items = append(a.items, "")
+	return a.enc.nested(a.key, func() error {
+		return a.enc.nested(index, func() error { return marshaler.MarshalLogObject(a.enc) })
+	})
+}
+
+// AppendReflected implements zapcore.ArrayEncoder.
+func (a *flatArrayEncoder) AppendReflected(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	a.items = append(a.items, string(data))
+	return nil
+}
+
+// AppendBool implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendBool(v bool) { a.items = append(a.items, strconv.FormatBool(v)) }
+
+// AppendByteString implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendByteString(v []byte) { a.items = append(a.items, string(v)) }
+
+// AppendComplex128 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendComplex128(v complex128) {
+	a.items = append(a.items, strconv.FormatComplex(v, 'g', -1, 128))
+}
+
+// AppendComplex64 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendComplex64(v complex64) {
+	a.items = append(a.items, strconv.FormatComplex(complex128(v), 'g', -1, 64))
+}
+
+// AppendFloat64 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendFloat64(v float64) {
+	a.items = append(a.items, strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+// AppendFloat32 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendFloat32(v float32) {
+	a.items = append(a.items, strconv.FormatFloat(float64(v), 'g', -1, 32))
+}
+
+// AppendInt implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendInt(v int) { a.AppendInt64(int64(v)) }
+
+// AppendInt64 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendInt64(v int64) { a.items = append(a.items, strconv.FormatInt(v, 10)) }
+
+// AppendInt32 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendInt32(v int32) { a.AppendInt64(int64(v)) }
+
+// AppendInt16 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendInt16(v int16) { a.AppendInt64(int64(v)) }
+
+// AppendInt8 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendInt8(v int8) { a.AppendInt64(int64(v)) }
+
+// AppendString implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendString(v string) { a.items = append(a.items, v) }
+
+// AppendUint implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendUint(v uint) { a.AppendUint64(uint64(v)) }
+
+// AppendUint64 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendUint64(v uint64) {
+	a.items = append(a.items, strconv.FormatUint(v, 10))
+}
+
+// AppendUint32 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendUint32(v uint32) { a.AppendUint64(uint64(v)) }
+
+// AppendUint16 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendUint16(v uint16) { a.AppendUint64(uint64(v)) }
+
+// AppendUint8 implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendUint8(v uint8) { a.AppendUint64(uint64(v)) }
+
+// AppendUintptr implements zapcore.PrimitiveArrayEncoder.
+func (a *flatArrayEncoder) AppendUintptr(v uintptr) { a.AppendUint64(uint64(v)) }
+
+// AppendDuration implements zapcore.ArrayEncoder.
+func (a *flatArrayEncoder) AppendDuration(v time.Duration) {
+	if a.enc.config.EncodeDuration != nil {
+		a.enc.config.EncodeDuration(v, a)
+		return
+	}
+	a.items = append(a.items, v.String())
+}
+
+// AppendTime implements zapcore.ArrayEncoder.
+func (a *flatArrayEncoder) AppendTime(v time.Time) {
+	if a.enc.config.EncodeTime != nil {
+		a.enc.config.EncodeTime(v, a)
+		return
+	}
+	a.items = append(a.items, v.Format(time.RFC3339Nano))
+}
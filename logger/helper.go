@@ -1,16 +1,15 @@
 package logger
 
 import (
-	"time"
-
 	"github.com/go-metaverse/zeri/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 // createLogger initializes a new zap.Logger based on the provided configuration.
-// It sets up the logger according to the specified settings for development or production
-// mode, configures the log level, and defines the encoder settings for formatting log messages.
+// It creates one core per configured sink, each with its own output, level, encoding and key names,
+// and tees them into a single logger. Without sinks, the logger writes to stderr using the top-level
+// level and encoding settings.
 //
 // Parameters:
 // - config: A pointer to a Config struct containing the configuration settings for the logger.
@@ -18,50 +17,184 @@ import (
 //
 // Returns:
 // - A pointer to the initialized zap.Logger instance.
+// - The resources owned by the logger, released with resources.close.
 // - An error if the logger could not be initialized.
-func createLogger(config *Config, opts ...zap.Option) (*zap.Logger, error) {
-	// Get the appropriate zap config (development or production)
-	zapConfig := getZapConfigByMode(config.EnableDevMode)
-
-	// Set log level
-	if !config.EnableDevMode {
-		zapConfig.Level = zap.NewAtomicLevelAt(logLevelMap[utils.DefaultIfEmpty(config.Level, LevelInfo)])
-	}
-
-	// Caller and stacktrace configuration
-	zapConfig.DisableCaller = config.DisableCaller
-	zapConfig.DisableStacktrace = config.DisableStacktrace
-
-	// Encode configuration
-	zapConfig.Encoding = string(getEncodeByMode(config.EnableDevMode, config.Encoding))
-	zapConfig.EncoderConfig = zapcore.EncoderConfig{
-		MessageKey:    "message",
-		TimeKey:       "time",
-		LevelKey:      "level",
-		NameKey:       "log",
-		CallerKey:     utils.OptionalKey(config.DisableCaller, "caller"),
-		StacktraceKey: utils.OptionalKey(config.DisableStacktrace, "stacktrace"),
-		EncodeLevel:   getEncodeLevelByMode(config.EnableDevMode),
-		EncodeTime:    getTimeEncoder,
-		EncodeCaller:  zapcore.FullCallerEncoder,
-	}
-
-	return zapConfig.Build(opts...)
+func createLogger(config *Config, opts ...zap.Option) (*zap.Logger, *resources, error) {
+	if err := config.Level.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sinks = []SinkConfig{{Output: OutputStderr}}
+	}
+
+	res := &resources{}
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for _, sink := range sinks {
+		core, err := newSinkCore(config, sink, res)
+		if err != nil {
+			_ = res.close()
+			return nil, nil, err
+		}
+		cores = append(cores, core)
+	}
+
+	// In-memory buffer of recent entries
+	if config.Recent != nil {
+		recent, err := newRecentBuffer(config.Recent)
+		if err != nil {
+			_ = res.close()
+			return nil, nil, err
+		}
+		level, err := ParseLevel(string(utils.DefaultIfEmpty(config.Recent.Level, LevelDebug)))
+		if err != nil {
+			_ = res.close()
+			return nil, nil, err
+		}
+		res.recent = recent
+		cores = append(cores, &recentCore{LevelEnabler: logLevelMap[level], buffer: recent})
+	}
+
+	errorOutput, _, err := zap.Open(OutputStderr)
+	if err != nil {
+		_ = res.close()
+		return nil, nil, err
+	}
+
+	// Hooks run from their own workers, alongside the sinks
+	hooks, err := newHookSet(config.Hooks, errorOutput)
+	if err != nil {
+		_ = res.close()
+		return nil, nil, err
+	}
+	res.hooks = hooks
+	res.addCloser(hooks.close)
+	cores = append(cores, &hookCore{set: hooks})
+
+	// Redaction configuration
+	if config.Redact != nil {
+		redactor, err := newRedactor(config.Redact)
+		if err != nil {
+			_ = res.close()
+			return nil, nil, err
+		}
+		opts = append([]zap.Option{zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newRedactCore(core, redactor)
+		})}, opts...)
+	}
+
+	// Sampling and duplicate suppression configuration
+	core := newSamplerCore(zapcore.NewTee(cores...), config, &res.sampling)
+	if config.Dedup != nil {
+		deduper := newDeduper(config.Dedup, &res.sampling)
+		res.addCloser(deduper.close)
+		core = newDedupCore(core, deduper)
+	}
+
+	// Error fields are structured and their stack traces extracted before any other processing
+	opts = append(opts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return newErrorCore(core, config.StructuredErrors)
+	}))
+
+	logger := zap.New(core, getOptionsByMode(config, errorOutput)...)
+	return logger.WithOptions(opts...), res, nil
 }
 
-// getZapConfigByMode returns the appropriate zap.Config based on the specified mode.
-// It configures the logger for either development or production use.
+// getOptionsByMode returns the zap options matching the specified mode. It configures the
+// error output and the caller and stacktrace annotations.
 //
 // Parameters:
-// - devMode: A boolean indicating whether to use development mode (true) or production mode (false).
+// - config: A pointer to a Config struct containing the configuration settings for the logger.
+// - errorOutput: The zapcore.WriteSyncer receiving internal logger errors.
 //
 // Returns:
-// - A zap.Config instance configured for the specified mode.
-func getZapConfigByMode(devMode bool) zap.Config {
-	if devMode {
-		return zap.NewDevelopmentConfig()
+// - A slice of zap options configured for the specified mode.
+func getOptionsByMode(config *Config, errorOutput zapcore.WriteSyncer) []zap.Option {
+	opts := []zap.Option{zap.ErrorOutput(errorOutput)}
+
+	if config.EnableDevMode {
+		opts = append(opts, zap.Development())
+	}
+
+	if !config.DisableCaller {
+		opts = append(opts, zap.AddCaller())
+	}
+
+	if !config.DisableStacktrace {
+		stackLevel := zapcore.ErrorLevel
+		if config.EnableDevMode {
+			stackLevel = zapcore.WarnLevel
+		}
+		opts = append(opts, zap.AddStacktrace(stackLevel))
 	}
-	return zap.NewProductionConfig()
+
+	return opts
+}
+
+// getLevelByMode returns the level of a sink. A level set on the sink always applies; otherwise
+// development mode logs at debug level and production mode uses the configured level.
+func getLevelByMode(devMode bool, level, sinkLevel LevelType) (zapcore.Level, error) {
+	if sinkLevel == "" && devMode {
+		return zapcore.DebugLevel, nil
+	}
+
+	parsed, err := ParseLevel(string(utils.DefaultIfEmpty(sinkLevel, utils.DefaultIfEmpty(level, LevelInfo))))
+	if err != nil {
+		return zapcore.InfoLevel, err
+	}
+	return logLevelMap[parsed], nil
+}
+
+// newEncoderConfig returns the encoder settings of a sink. The layout of the sink, or the
+// logger layout when the sink has none, is resolved against its preset, and the key names
+// set on the sink take precedence.
+//
+// Parameters:
+// - config: A pointer to a Config struct containing the configuration settings for the logger.
+// - encoding: The encoding of the sink.
+// - sink: The SinkConfig overriding the layout and keys.
+//
+// Returns:
+// - A zapcore.EncoderConfig for the sink.
+// - The fields added to every entry by the layout preset.
+// - An error if the layout contains an unknown setting.
+func newEncoderConfig(config *Config, encoding EncodingType, sink SinkConfig) (zapcore.EncoderConfig, []zap.Field, error) {
+	layout := config.Layout
+	if sink.Layout != nil {
+		layout = *sink.Layout
+	}
+
+	layout, fields, err := resolveLayout(layout, sink.Keys)
+	if err != nil {
+		return zapcore.EncoderConfig{}, nil, err
+	}
+
+	encodeTime, err := getTimeEncoderByFormat(layout.TimeFormat, layout.TimeZone)
+	if err != nil {
+		return zapcore.EncoderConfig{}, nil, err
+	}
+	encodeCaller, err := getCallerEncoderByFormat(layout.CallerFormat)
+	if err != nil {
+		return zapcore.EncoderConfig{}, nil, err
+	}
+	encodeLevel, err := getLevelEncoderByFormat(layout.LevelFormat, config.EnableDevMode && encoding == EncodingConsole)
+	if err != nil {
+		return zapcore.EncoderConfig{}, nil, err
+	}
+
+	keys := layout.Keys
+	return zapcore.EncoderConfig{
+		MessageKey:    layoutKey(keys.MessageKey, "message"),
+		TimeKey:       layoutKey(keys.TimeKey, "time"),
+		LevelKey:      layoutKey(keys.LevelKey, "level"),
+		NameKey:       layoutKey(keys.NameKey, "log"),
+		CallerKey:     utils.OptionalKey(config.DisableCaller, layoutKey(keys.CallerKey, "caller")),
+		StacktraceKey: utils.OptionalKey(config.DisableStacktrace, layoutKey(keys.StacktraceKey, "stacktrace")),
+		EncodeLevel:   encodeLevel,
+		EncodeTime:    encodeTime,
+		EncodeCaller:  encodeCaller,
+	}, fields, nil
 }
 
 func getEncodeByMode(devMode bool, encode EncodingType) EncodingType {
@@ -86,14 +219,3 @@ func getEncodeLevelByMode(devMode bool) zapcore.LevelEncoder {
 	}
 	return zapcore.CapitalLevelEncoder
 }
-
-// getTimeEncoder is a custom time encoder used for logging timestamps in a specific format.
-// It formats the given time as a string using RFC3339 format and appends it to the
-// provided PrimitiveArrayEncoder.
-//
-// Parameters:
-// - t: The time to be formatted and encoded.
-// - enc: The zapcore.PrimitiveArrayEncoder to which the formatted time string will be appended.
-func getTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-	enc.AppendString(t.Format(time.RFC3339))
-}
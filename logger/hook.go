@@ -0,0 +1,434 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// Hook defaults
+const (
+	defaultHookQueueSize    = 64
+	defaultHookRateInterval = time.Minute
+	defaultHookTimeout      = 10 * time.Second
+)
+
+// HookFunc is called with the entries accepted by a hook. The context is canceled once the
+// hook timeout elapses. A returned error is reported to the error output of the logger.
+type HookFunc func(ctx context.Context, entry HookEntry) error
+
+// HookEntry is the entry passed to a hook, along with its fields and the fields of the logger.
+type HookEntry struct {
+	zapcore.Entry
+	Fields map[string]any
+}
+
+type Hook struct {
+	// Name identifies the hook in error reports and HookStats.
+	Name string
+
+	// Level is the minimum level of the entries passed to the hook (default: error).
+	Level LevelType
+
+	// Filter, when set, passes only the entries for which it returns true.
+	Filter func(HookEntry) bool
+
+	// RateLimit is the maximum number of calls per RateInterval; further entries are skipped
+	// (default: unlimited).
+	RateLimit int
+
+	// RateInterval is the window of RateLimit (default: 1m).
+	RateInterval time.Duration
+
+	// Timeout bounds each call through its context, and how long a panic or fatal entry waits
+	// for the hook before the process goes down (default: 10s).
+	Timeout time.Duration
+
+	// QueueSize is the number of entries waiting for the hook before new ones are dropped (default: 64).
+	QueueSize int
+
+	// Func is the callback.
+	Func HookFunc
+}
+
+// HookStats reports the counters of a hook.
+type HookStats struct {
+	// Name identifies the hook.
+	Name string
+
+	// Called is the number of calls of the hook.
+	Called uint64
+
+	// Failed is the number of calls that returned an error, panicked or exceeded the timeout.
+	Failed uint64
+
+	// Dropped is the number of entries discarded because the queue of the hook was full.
+	Dropped uint64
+
+	// RateLimited is the number of entries skipped by the rate limit.
+	RateLimited uint64
+}
+
+// GoroutineDumpHook returns a Hook writing the stack traces of all goroutines to w when a
+// fatal entry is logged.
+//
+// Usage example:
+//
+//	logger.InitLogger(&logger.Config{Hooks: []logger.Hook{logger.GoroutineDumpHook(os.Stderr)}})
+func GoroutineDumpHook(w io.Writer) Hook {
+	return Hook{
+		Name:  "goroutine_dump",
+		Level: LevelFatal,
+		Func: func(_ context.Context, _ HookEntry) error {
+			return pprof.Lookup("goroutine").WriteTo(w, 2)
+		},
+	}
+}
+
+// hookJob is an entry waiting in the queue of a hook. done is closed once the hook returns
+// when the logger must wait for it.
+type hookJob struct {
+	entry HookEntry
+	done  chan struct{}
+}
+
+// hookRunner calls a hook from its own worker, so a slow or panicking hook never blocks logging.
+type hookRunner struct {
+	hook        Hook
+	level       zapcore.Level
+	interval    time.Duration
+	timeout     time.Duration
+	errorOutput zapcore.WriteSyncer
+
+	mu     sync.RWMutex
+	queue  chan hookJob
+	closed bool
+	done   chan struct{}
+
+	windowStart time.Time
+	windowCount int
+
+	called      atomic.Uint64
+	failed      atomic.Uint64
+	dropped     atomic.Uint64
+	rateLimited atomic.Uint64
+}
+
+// newHookRunner validates hook and starts its worker.
+func newHookRunner(hook Hook, errorOutput zapcore.WriteSyncer) (*hookRunner, error) {
+	if hook.Func == nil {
+		return nil, fmt.Errorf("hook %q has no Func", hook.Name)
+	}
+
+	level, err := ParseLevel(string(utils.DefaultIfEmpty(hook.Level, LevelError)))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &hookRunner{
+		hook:        hook,
+		level:       logLevelMap[level],
+		interval:    utils.DefaultIfEmpty(hook.RateInterval, defaultHookRateInterval),
+		timeout:     utils.DefaultIfEmpty(hook.Timeout, defaultHookTimeout),
+		errorOutput: errorOutput,
+		queue:       make(chan hookJob, utils.DefaultIfEmpty(hook.QueueSize, defaultHookQueueSize)),
+		done:        make(chan struct{}),
+	}
+
+	go r.run()
+	return r, nil
+}
+
+// enqueue hands entry to the worker without blocking, dropping it when the queue is full.
+// When wait is set, it waits until the hook returns or the timeout elapses instead.
+func (r *hookRunner) enqueue(entry HookEntry, wait bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return
+	}
+
+	if !wait {
+		select {
+		case r.queue <- hookJob{entry: entry}:
+		default:
+			r.dropped.Add(1)
+		}
+		return
+	}
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	job := hookJob{entry: entry, done: make(chan struct{})}
+	select {
+	case r.queue <- job:
+	case <-timer.C:
+		r.dropped.Add(1)
+		return
+	}
+	select {
+	case <-job.done:
+	case <-timer.C:
+	}
+}
+
+// run is the worker of the hook.
+func (r *hookRunner) run() {
+	defer close(r.done)
+	for job := range r.queue {
+		r.call(job.entry)
+		if job.done != nil {
+			close(job.done)
+		}
+	}
+}
+
+// call runs the filter, rate limit and callback of the hook, reporting failures.
+func (r *hookRunner) call(entry HookEntry) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r.report(fmt.Errorf("panic: %v", recovered))
+		}
+	}()
+
+	if r.hook.Filter != nil && !r.hook.Filter(entry) {
+		return
+	}
+	if !r.allow(entry.Time) {
+		r.rateLimited.Add(1)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	r.called.Add(1)
+	start := time.Now()
+	err := r.hook.Func(ctx, entry)
+	if err == nil && time.Since(start) > r.timeout {
+		err = fmt.Errorf("took %s, exceeding the timeout of %s", time.Since(start).Round(time.Millisecond), r.timeout)
+	}
+	if err != nil {
+		r.report(err)
+	}
+}
+
+// allow reports whether the rate limit lets another call through in the current window.
+// It is only called by the worker.
+func (r *hookRunner) allow(now time.Time) bool {
+	if r.hook.RateLimit <= 0 {
+		return true
+	}
+	if now.Sub(r.windowStart) >= r.interval {
+		r.windowStart, r.windowCount = now, 0
+	}
+	if r.windowCount >= r.hook.RateLimit {
+		return false
+	}
+	r.windowCount++
+	return true
+}
+
+// report writes a hook failure to the error output of the logger.
+func (r *hookRunner) report(err error) {
+	r.failed.Add(1)
+	_, _ = fmt.Fprintf(r.errorOutput, "%v hook %q failed: %v\n", time.Now().UTC(), r.hook.Name, err)
+	_ = r.errorOutput.Sync()
+}
+
+// close stops accepting entries and waits for the queued ones, up to the hook timeout.
+func (r *hookRunner) close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.queue)
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-time.After(r.timeout):
+		return fmt.Errorf("hook %q did not finish within %s", r.hook.Name, r.timeout)
+	}
+}
+
+// stats returns the counters of the hook.
+func (r *hookRunner) stats() HookStats {
+	return HookStats{
+		Name:        r.hook.Name,
+		Called:      r.called.Load(),
+		Failed:      r.failed.Load(),
+		Dropped:     r.dropped.Load(),
+		RateLimited: r.rateLimited.Load(),
+	}
+}
+
+// hookSet holds the hooks of a logger. Hooks can be added and removed while logging.
+type hookSet struct {
+	errorOutput zapcore.WriteSyncer
+
+	mu      sync.RWMutex
+	runners []*hookRunner
+
+	// minLevel is the lowest level of the hooks, above FatalLevel when there are none.
+	minLevel atomic.Int32
+}
+
+// newHookSet creates a hookSet running hooks.
+func newHookSet(hooks []Hook, errorOutput zapcore.WriteSyncer) (*hookSet, error) {
+	s := &hookSet{errorOutput: errorOutput}
+	s.minLevel.Store(int32(zapcore.FatalLevel + 1))
+
+	for _, hook := range hooks {
+		if _, err := s.add(hook); err != nil {
+			_ = s.close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// add starts hook and returns a function removing it.
+func (s *hookSet) add(hook Hook) (func(), error) {
+	runner, err := newHookRunner(hook, s.errorOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.runners = append(s.runners, runner)
+	s.updateMinLevel()
+	s.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			for i, r := range s.runners {
+				if r == runner {
+					s.runners = append(s.runners[:i:i], s.runners[i+1:]...)
+					break
+				}
+			}
+			s.updateMinLevel()
+			s.mu.Unlock()
+			_ = runner.close()
+		})
+	}, nil
+}
+
+// updateMinLevel recomputes the lowest level of the hooks. It must be called with mu held.
+func (s *hookSet) updateMinLevel() {
+	level := zapcore.FatalLevel + 1
+	for _, runner := range s.runners {
+		level = min(level, runner.level)
+	}
+	s.minLevel.Store(int32(level))
+}
+
+// Enabled implements zapcore.LevelEnabler.
+func (s *hookSet) Enabled(level zapcore.Level) bool {
+	return int32(level) >= s.minLevel.Load()
+}
+
+// close stops every hook after its queued entries are handled.
+func (s *hookSet) close() error {
+	s.mu.Lock()
+	runners := s.runners
+	s.runners = nil
+	s.updateMinLevel()
+	s.mu.Unlock()
+
+	var err error
+	for _, runner := range runners {
+		err = multierr.Append(err, runner.close())
+	}
+	return err
+}
+
+// stats returns the counters of the hooks.
+func (s *hookSet) stats() []HookStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := make([]HookStats, 0, len(s.runners))
+	for _, runner := range s.runners {
+		stats = append(stats, runner.stats())
+	}
+	return stats
+}
+
+// hookCore is a zapcore.Core passing entries to the hooks of a hookSet.
+type hookCore struct {
+	set    *hookSet
+	fields []zapcore.Field
+}
+
+// Enabled implements zapcore.Core.
+func (c *hookCore) Enabled(level zapcore.Level) bool {
+	return c.set.Enabled(level)
+}
+
+// With implements zapcore.Core.
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...)
+	return &clone
+}
+
+// Check implements zapcore.Core.
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core. Entries that bring the process down wait for the hooks, so
+// hooks such as GoroutineDumpHook run before the process exits.
+func (c *hookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.set.mu.RLock()
+	runners := c.set.runners
+	c.set.mu.RUnlock()
+
+	var entry *HookEntry
+	for _, runner := range runners {
+		if ent.Level < runner.level {
+			continue
+		}
+		if entry == nil {
+			entry = newHookEntry(ent, c.fields, fields)
+		}
+		runner.enqueue(*entry, ent.Level >= zapcore.DPanicLevel)
+	}
+	return nil
+}
+
+// Sync implements zapcore.Core.
+func (c *hookCore) Sync() error {
+	return nil
+}
+
+// newHookEntry encodes the context and entry fields into a HookEntry.
+func newHookEntry(ent zapcore.Entry, context, fields []zapcore.Field) *HookEntry {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range context {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	return &HookEntry{Entry: ent, Fields: enc.Fields}
+}
@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultJournaldSocket is the native protocol socket of systemd-journald.
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+type JournaldConfig struct {
+	// Socket is the path of the journald native protocol socket (default: /run/systemd/journal/socket).
+	Socket string
+
+	// SyslogIdentifier is sent as SYSLOG_IDENTIFIER (default: the executable name).
+	SyslogIdentifier string
+
+	// Reconnect controls how a broken socket is re-opened.
+	Reconnect ReconnectConfig
+}
+
+// journaldCore is a zapcore.Core that sends entries to journald as structured fields using
+// the native protocol. Field keys are converted to uppercase journal field names.
+type journaldCore struct {
+	zapcore.LevelEnabler
+	conn       *reconnectingConn
+	identifier string
+	fields     []zapcore.Field
+}
+
+// newJournaldCore creates a zapcore.Core writing to the journald socket described by config.
+//
+// Parameters:
+// - config: A pointer to a JournaldConfig struct describing the socket.
+// - level: The zapcore.LevelEnabler deciding which entries are sent.
+//
+// Returns:
+// - The zapcore.Core writing to journald.
+func newJournaldCore(config *JournaldConfig, level zapcore.LevelEnabler) *journaldCore {
+	return &journaldCore{
+		LevelEnabler: level,
+		conn:         newReconnectingConn("unixgram", utils.DefaultIfEmpty(config.Socket, DefaultJournaldSocket), config.Reconnect),
+		identifier:   utils.DefaultIfEmpty(config.SyslogIdentifier, filepath.Base(os.Args[0])),
+	}
+}
+
+// With implements zapcore.Core.
+func (c *journaldCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...)
+	return &clone
+}
+
+// Check implements zapcore.Core.
+func (c *journaldCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *journaldCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	var msg bytes.Buffer
+	writeJournalField(&msg, "MESSAGE", ent.Message)
+	writeJournalField(&msg, "PRIORITY", strconv.Itoa(syslogSeverityMap[ent.Level]))
+	writeJournalField(&msg, "SYSLOG_IDENTIFIER", c.identifier)
+	if ent.LoggerName != "" {
+		writeJournalField(&msg, "LOGGER_NAME", ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		writeJournalField(&msg, "CODE_FILE", ent.Caller.File)
+		writeJournalField(&msg, "CODE_LINE", strconv.Itoa(ent.Caller.Line))
+		writeJournalField(&msg, "CODE_FUNC", ent.Caller.Function)
+	}
+	if ent.Stack != "" {
+		writeJournalField(&msg, "STACKTRACE", ent.Stack)
+	}
+
+	keys := make([]string, 0, len(enc.Fields))
+	for key := range enc.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeJournalField(&msg, journalFieldName(key), journalFieldValue(enc.Fields[key]))
+	}
+
+	return c.conn.write(msg.Bytes())
+}
+
+// Sync implements zapcore.Core.
+func (c *journaldCore) Sync() error {
+	return nil
+}
+
+// writeJournalField appends a field in the native protocol format. Values containing a newline
+// are written as the name, a newline, the little-endian 64-bit length and the raw value.
+func writeJournalField(buf *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		fmt.Fprintf(buf, "%s=%s\n", name, value)
+		return
+	}
+
+	buf.WriteString(name)
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName converts key into a valid journal field name: uppercase letters, digits
+// and underscores, not starting with an underscore or a digit.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	name = strings.TrimLeft(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "F_" + name
+	}
+	return name
+}
+
+// journalFieldValue formats a field value, encoding nested values as JSON.
+func journalFieldValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
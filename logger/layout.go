@@ -0,0 +1,281 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Layout presets
+const (
+	PresetECS     LayoutPreset = "ecs"
+	PresetGCP     LayoutPreset = "gcp"
+	PresetDatadog LayoutPreset = "datadog"
+)
+
+// Time formats
+const (
+	TimeRFC3339      TimeFormat = "rfc3339"
+	TimeRFC3339Milli TimeFormat = "rfc3339milli"
+	TimeRFC3339Nano  TimeFormat = "rfc3339nano"
+	TimeUnix         TimeFormat = "unix"
+	TimeUnixMilli    TimeFormat = "unixmilli"
+	TimeUnixNano     TimeFormat = "unixnano"
+)
+
+// Caller formats
+const (
+	CallerFull  CallerFormat = "full"
+	CallerShort CallerFormat = "short"
+)
+
+// Level formats
+const (
+	LevelCapital      LevelFormat = "capital"
+	LevelCapitalColor LevelFormat = "capital_color"
+	LevelLower        LevelFormat = "lower"
+	LevelLowerColor   LevelFormat = "lower_color"
+	LevelGCP          LevelFormat = "gcp"
+)
+
+// OmitKey disables a key when used as a KeyConfig value.
+const OmitKey = "-"
+
+type LayoutPreset string
+
+type TimeFormat string
+
+type CallerFormat string
+
+type LevelFormat string
+
+type LayoutConfig struct {
+	// Preset applies the key names and formats expected by a log backend (accepts: PresetECS,
+	// PresetGCP, PresetDatadog). Other layout settings override the preset.
+	Preset LayoutPreset `json:"preset" yaml:"preset"`
+
+	// Keys overrides the field names used by the encoder.
+	Keys KeyConfig `json:"keys" yaml:"keys"`
+
+	// TimeFormat specifies how timestamps are written (default: rfc3339, accepts: the TimeFormat
+	// constants or a Go time layout such as "2006-01-02 15:04:05.000").
+	TimeFormat TimeFormat `json:"time_format" yaml:"time_format"`
+
+	// TimeZone specifies the zone of timestamps (default: local, accepts: local, utc or an IANA name).
+	TimeZone string `json:"time_zone" yaml:"time_zone"`
+
+	// CallerFormat specifies how the caller is written (default: full, accepts: full, short).
+	CallerFormat CallerFormat `json:"caller_format" yaml:"caller_format"`
+
+	// LevelFormat specifies the casing of levels (default: capital, or capital_color for the
+	// console encoding in development mode; accepts: capital, capital_color, lower, lower_color, gcp).
+	LevelFormat LevelFormat `json:"level_format" yaml:"level_format"`
+}
+
+// layoutPresets holds the defaults of each preset, along with the fields they add to every entry.
+var layoutPresets = map[LayoutPreset]struct {
+	layout LayoutConfig
+	fields []zap.Field
+}{
+	PresetECS: {
+		layout: LayoutConfig{
+			Keys: KeyConfig{
+				MessageKey:    "message",
+				TimeKey:       "@timestamp",
+				LevelKey:      "log.level",
+				NameKey:       "log.logger",
+				CallerKey:     "log.origin.file.name",
+				StacktraceKey: "error.stack_trace",
+			},
+			TimeFormat:  TimeRFC3339Milli,
+			TimeZone:    "utc",
+			LevelFormat: LevelLower,
+		},
+		fields: []zap.Field{zap.String("ecs.version", "8.11.0")},
+	},
+	PresetGCP: {
+		layout: LayoutConfig{
+			Keys: KeyConfig{
+				MessageKey:    "message",
+				TimeKey:       "timestamp",
+				LevelKey:      "severity",
+				NameKey:       "logger",
+				CallerKey:     "caller",
+				StacktraceKey: "stack_trace",
+			},
+			TimeFormat:  TimeRFC3339Nano,
+			TimeZone:    "utc",
+			LevelFormat: LevelGCP,
+		},
+	},
+	PresetDatadog: {
+		layout: LayoutConfig{
+			Keys: KeyConfig{
+				MessageKey:    "message",
+				TimeKey:       "timestamp",
+				LevelKey:      "status",
+				NameKey:       "logger.name",
+				CallerKey:     "logger.caller",
+				StacktraceKey: "error.stack",
+			},
+			TimeFormat:  TimeUnixMilli,
+			TimeZone:    "utc",
+			LevelFormat: LevelLower,
+		},
+	},
+}
+
+// gcpSeverityMap maps zap levels to Cloud Logging severities.
+var gcpSeverityMap = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "DEBUG",
+	zapcore.InfoLevel:   "INFO",
+	zapcore.WarnLevel:   "WARNING",
+	zapcore.ErrorLevel:  "ERROR",
+	zapcore.DPanicLevel: "CRITICAL",
+	zapcore.PanicLevel:  "ALERT",
+	zapcore.FatalLevel:  "EMERGENCY",
+}
+
+// resolveLayout merges layout over its preset, then applies the sink key overrides.
+//
+// Parameters:
+// - layout: The LayoutConfig of the logger or the sink.
+// - keys: The key names overridden by the sink.
+//
+// Returns:
+// - The resolved LayoutConfig.
+// - The fields added to every entry by the preset.
+// - An error if the preset is unknown.
+func resolveLayout(layout LayoutConfig, keys KeyConfig) (LayoutConfig, []zap.Field, error) {
+	var fields []zap.Field
+	if layout.Preset != "" {
+		preset, ok := layoutPresets[LayoutPreset(strings.ToLower(string(layout.Preset)))]
+		if !ok {
+			return layout, nil, fmt.Errorf("unknown log layout preset %q (accepts: ecs, gcp, datadog)", layout.Preset)
+		}
+		fields = preset.fields
+
+		layout.Keys = mergeKeys(preset.layout.Keys, layout.Keys)
+		layout.TimeFormat = utils.DefaultIfEmpty(layout.TimeFormat, preset.layout.TimeFormat)
+		layout.TimeZone = utils.DefaultIfEmpty(layout.TimeZone, preset.layout.TimeZone)
+		layout.CallerFormat = utils.DefaultIfEmpty(layout.CallerFormat, preset.layout.CallerFormat)
+		layout.LevelFormat = utils.DefaultIfEmpty(layout.LevelFormat, preset.layout.LevelFormat)
+	}
+
+	layout.Keys = mergeKeys(layout.Keys, keys)
+	return layout, fields, nil
+}
+
+// mergeKeys returns base with the keys set in override replacing its own.
+func mergeKeys(base, override KeyConfig) KeyConfig {
+	return KeyConfig{
+		MessageKey:    utils.DefaultIfEmpty(override.MessageKey, base.MessageKey),
+		TimeKey:       utils.DefaultIfEmpty(override.TimeKey, base.TimeKey),
+		LevelKey:      utils.DefaultIfEmpty(override.LevelKey, base.LevelKey),
+		NameKey:       utils.DefaultIfEmpty(override.NameKey, base.NameKey),
+		CallerKey:     utils.DefaultIfEmpty(override.CallerKey, base.CallerKey),
+		StacktraceKey: utils.DefaultIfEmpty(override.StacktraceKey, base.StacktraceKey),
+	}
+}
+
+// layoutKey returns key, the fallback when key is empty, or no key at all when key is OmitKey.
+func layoutKey(key, fallback string) string {
+	if key == OmitKey {
+		return ""
+	}
+	return utils.DefaultIfEmpty(key, fallback)
+}
+
+// getTimeEncoderByFormat returns the time encoder writing timestamps in the given format and zone.
+//
+// Parameters:
+// - format: The TimeFormat, or a Go time layout.
+// - zone: The time zone: local, utc or an IANA name.
+//
+// Returns:
+// - The zapcore.TimeEncoder.
+// - An error if the zone cannot be loaded.
+func getTimeEncoderByFormat(format TimeFormat, zone string) (zapcore.TimeEncoder, error) {
+	location, err := loadLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := string(format)
+	switch TimeFormat(strings.ToLower(layout)) {
+	case "", TimeRFC3339:
+		layout = time.RFC3339
+	case TimeRFC3339Milli:
+		layout = "2006-01-02T15:04:05.000Z07:00"
+	case TimeRFC3339Nano:
+		layout = time.RFC3339Nano
+	case TimeUnix:
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) { enc.AppendInt64(t.Unix()) }, nil
+	case TimeUnixMilli:
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) { enc.AppendInt64(t.UnixMilli()) }, nil
+	case TimeUnixNano:
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) { enc.AppendInt64(t.UnixNano()) }, nil
+	}
+
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		if location != nil {
+			t = t.In(location)
+		}
+		enc.AppendString(t.Format(layout))
+	}, nil
+}
+
+// loadLocation returns the location of zone, or nil for the local zone.
+func loadLocation(zone string) (*time.Location, error) {
+	switch strings.ToLower(zone) {
+	case "", "local":
+		return nil, nil
+	case "utc":
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown log time zone %q: %w", zone, err)
+	}
+	return location, nil
+}
+
+// getCallerEncoderByFormat returns the caller encoder for the given format.
+func getCallerEncoderByFormat(format CallerFormat) (zapcore.CallerEncoder, error) {
+	switch CallerFormat(strings.ToLower(string(format))) {
+	case "", CallerFull:
+		return zapcore.FullCallerEncoder, nil
+	case CallerShort:
+		return zapcore.ShortCallerEncoder, nil
+	default:
+		return nil, fmt.Errorf("unknown log caller format %q (accepts: full, short)", format)
+	}
+}
+
+// getLevelEncoderByFormat returns the level encoder for the given format. Without a format,
+// the level is colored for the console encoding in development mode.
+func getLevelEncoderByFormat(format LevelFormat, colored bool) (zapcore.LevelEncoder, error) {
+	switch LevelFormat(strings.ToLower(string(format))) {
+	case "":
+		return getEncodeLevelByMode(colored), nil
+	case LevelCapital:
+		return zapcore.CapitalLevelEncoder, nil
+	case LevelCapitalColor:
+		return zapcore.CapitalColorLevelEncoder, nil
+	case LevelLower:
+		return zapcore.LowercaseLevelEncoder, nil
+	case LevelLowerColor:
+		return zapcore.LowercaseColorLevelEncoder, nil
+	case LevelGCP:
+		return func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(utils.DefaultIfEmpty(gcpSeverityMap[level], "DEFAULT"))
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown log level format %q (accepts: capital, capital_color, lower, lower_color, gcp)", format)
+	}
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ParseLevel parses a log level name case-insensitively. Besides the LevelType constants,
+// it accepts the aliases "warning", "err" and "critical".
+//
+// Parameters:
+// - s: The level name to parse.
+//
+// Returns:
+// - The matching LevelType.
+// - An error if s is not a known level.
+func ParseLevel(s string) (LevelType, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if level, ok := logLevelAliases[name]; ok {
+		return level, nil
+	}
+	if _, ok := logLevelMap[LevelType(name)]; ok {
+		return LevelType(name), nil
+	}
+	return "", fmt.Errorf("unknown log level %q (accepts: debug, info, warn, error, dpanic, panic, fatal)", s)
+}
+
+// ParseEncoding parses a log encoding name case-insensitively.
+//
+// Parameters:
+// - s: The encoding name to parse.
+//
+// Returns:
+// - The matching EncodingType.
+// - An error if s is not a known encoding.
+func ParseEncoding(s string) (EncodingType, error) {
+	switch encoding := EncodingType(strings.ToLower(strings.TrimSpace(s))); encoding {
+	case EncodingJSON, EncodingConsole, EncodingLogfmt, EncodingPretty:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("unknown log encoding %q (accepts: json, console, logfmt, pretty)", s)
+	}
+}
+
+// ZapLevel returns the zap level matching the log level. Unknown levels map to info;
+// use ParseLevel or Validate to reject them.
+func (l LevelType) ZapLevel() zapcore.Level {
+	if level, err := ParseLevel(string(l)); err == nil {
+		return logLevelMap[level]
+	}
+	return zapcore.InfoLevel
+}
+
+// Validate reports an error if l is neither empty nor a known level.
+func (l LevelType) Validate() error {
+	if l == "" {
+		return nil
+	}
+	_, err := ParseLevel(string(l))
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, used when decoding JSON.
+// An empty value leaves the level unset so that the default applies.
+func (l *LevelType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*l = ""
+		return nil
+	}
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *LevelType) UnmarshalYAML(unmarshal func(any) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(text))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, used when decoding JSON.
+// An empty value leaves the encoding unset so that the default applies.
+func (e *EncodingType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*e = ""
+		return nil
+	}
+	encoding, err := ParseEncoding(string(text))
+	if err != nil {
+		return err
+	}
+	*e = encoding
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (e *EncodingType) UnmarshalYAML(unmarshal func(any) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(text))
+}
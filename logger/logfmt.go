@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// logfmtPool provides the buffers of encoded entries.
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder is a zapcore.Encoder writing entries as a single line of key=value pairs.
+// Nested objects are flattened into dotted keys and values are quoted when needed.
+type logfmtEncoder struct {
+	*flatEncoder
+}
+
+// newLogfmtEncoder creates a logfmt encoder using the given encoder settings.
+func newLogfmtEncoder(config zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{flatEncoder: newFlatEncoder(&config)}
+}
+
+// Clone implements zapcore.Encoder.
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	return &logfmtEncoder{flatEncoder: e.clone()}
+}
+
+// EncodeEntry implements zapcore.Encoder.
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line := newFlatEncoder(e.config)
+	addEntryMetadata(line, ent)
+
+	enc := e.clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	buf := logfmtPool.Get()
+	for _, field := range append(line.fields, enc.fields...) {
+		writeLogfmtPair(buf, strings.Join(field.path, "."), field.value)
+	}
+	if ent.Stack != "" && e.config.StacktraceKey != "" {
+		writeLogfmtPair(buf, e.config.StacktraceKey, ent.Stack)
+	}
+	buf.AppendString(e.config.LineEnding)
+	if e.config.LineEnding == "" {
+		buf.AppendString(zapcore.DefaultLineEnding)
+	}
+
+	return buf, nil
+}
+
+// addEntryMetadata records the time, level, logger name, caller and message of ent under
+// the configured keys.
+func addEntryMetadata(enc *flatEncoder, ent zapcore.Entry) {
+	config := enc.config
+	if config.TimeKey != "" && config.EncodeTime != nil && !ent.Time.IsZero() {
+		enc.add(config.TimeKey, enc.primitive(func(arr zapcore.PrimitiveArrayEncoder) { config.EncodeTime(ent.Time, arr) }))
+	}
+	if config.LevelKey != "" && config.EncodeLevel != nil {
+		enc.add(config.LevelKey, enc.primitive(func(arr zapcore.PrimitiveArrayEncoder) { config.EncodeLevel(ent.Level, arr) }))
+	}
+	if config.NameKey != "" && ent.LoggerName != "" {
+		enc.add(config.NameKey, ent.LoggerName)
+	}
+	if config.CallerKey != "" && ent.Caller.Defined && config.EncodeCaller != nil {
+		enc.add(config.CallerKey, enc.primitive(func(arr zapcore.PrimitiveArrayEncoder) { config.EncodeCaller(ent.Caller, arr) }))
+	}
+	if config.FunctionKey != "" && ent.Caller.Defined {
+		enc.add(config.FunctionKey, ent.Caller.Function)
+	}
+	if config.MessageKey != "" {
+		enc.add(config.MessageKey, ent.Message)
+	}
+}
+
+// writeLogfmtPair appends key=value to buf, separated from the previous pair by a space.
+func writeLogfmtPair(buf *buffer.Buffer, key, value string) {
+	if buf.Len() > 0 {
+		buf.AppendByte(' ')
+	}
+	buf.AppendString(logfmtKey(key))
+	buf.AppendByte('=')
+	if logfmtNeedsQuote(value) {
+		buf.AppendString(strconv.Quote(value))
+		return
+	}
+	buf.AppendString(value)
+}
+
+// logfmtKey replaces the characters that are not allowed in a logfmt key.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtNeedsQuote reports whether value must be quoted to be parsed back unambiguously.
+func logfmtNeedsQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
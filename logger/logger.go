@@ -1,17 +1,93 @@
 package logger
 
 import (
+	"errors"
 	"log"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
 // ZeriLogger is a global instance of a SugaredLogger for convenient logging throughout the application.
 var ZeriLogger *zap.SugaredLogger
 
+// zeriResources holds the resources owned by the global logger.
+var zeriResources = &resources{}
+
+// Logger is a SugaredLogger that owns the outputs and background workers created from its
+// configuration. Close releases them once the logger is no longer used.
+type Logger struct {
+	*zap.SugaredLogger
+	res *resources
+}
+
+// NewLogger creates a Logger based on the provided configuration without touching the global
+// ZeriLogger. Unlike InitLogger, it reports configuration errors instead of exiting.
+//
+// Parameters:
+// - cfg: A pointer to a Config struct containing the configuration settings for the logger.
+// - opts: Optional zap options for customizing the logger further.
+//
+// Returns:
+// - A pointer to the initialized Logger.
+// - An error if the configuration is invalid or an output could not be opened.
+//
+// Usage example:
+//
+//	log, err := logger.NewLogger(&logger.Config{Level: "WARN"})
+//	if err != nil {
+//	    return err
+//	}
+//	defer log.Close()
+func NewLogger(cfg *Config, opts ...zap.Option) (*Logger, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	logger, res, err := createLogger(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Logger{SugaredLogger: logger.Sugar(), res: res}, nil
+}
+
+// Close flushes the logger and releases its outputs and background workers.
+func (l *Logger) Close() error {
+	return multierr.Append(l.Sync(), l.res.close())
+}
+
+// AsyncStats returns the counters of the asynchronous sinks of the logger.
+func (l *Logger) AsyncStats() []AsyncStats {
+	return l.res.asyncStats()
+}
+
+// SamplingStats returns the sampling and duplicate suppression counters of the logger.
+func (l *Logger) SamplingStats() SamplingStats {
+	return l.res.sampling.stats()
+}
+
+// AddHook starts a hook on the logger while it is running.
+//
+// Parameters:
+// - hook: The Hook to add.
+//
+// Returns:
+// - A function removing the hook once its queued entries are handled.
+// - An error if the hook is invalid.
+func (l *Logger) AddHook(hook Hook) (func(), error) {
+	return l.res.hooks.add(hook)
+}
+
+// HookStats returns the counters of the hooks of the logger.
+func (l *Logger) HookStats() []HookStats {
+	return l.res.hookStats()
+}
+
 // InitLogger initializes the global ZeriLogger instance based on the provided configuration.
 // It sets up a new zap.Logger according to the specified settings and options. If the logger
-// initialization fails, it logs a fatal error and terminates the application.
+// initialization fails, it logs a fatal error and terminates the application; use NewLogger
+// to handle the error instead.
 //
 // Parameters:
 // - cfg: A pointer to a Config struct containing the configuration settings for the logger.
@@ -29,15 +105,47 @@ var ZeriLogger *zap.SugaredLogger
 //
 //	zeriLogger.Info("Logger initialized successfully")
 func InitLogger(cfg *Config, opts ...zap.Option) (*zap.SugaredLogger, func()) {
-	logger, err := createLogger(cfg, opts...)
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	instance, err := NewLogger(cfg, opts...)
 	if err != nil {
 		log.Fatalf("failed to initialize logger: %v", err)
 	}
+	zeriResources = instance.res
+
+	logger := instance.Desugar()
+	setTraceKeys(cfg)
+	undo := ReplaceGlobals(logger)
+
+	if cfg.EnableSlog {
+		undoGlobals, undoSlog := undo, SetSlogDefault(logger)
+		undo = func() {
+			undoSlog()
+			undoGlobals()
+		}
+	}
+
+	return ZeriLogger, undo
+}
 
+// ReplaceGlobals replaces the global ZeriLogger and the zap globals with the provided logger.
+//
+// Parameters:
+// - logger: The zap.Logger to install as the global logger.
+//
+// Returns:
+// - A function that restores the previous global loggers.
+func ReplaceGlobals(logger *zap.Logger) func() {
+	prev := ZeriLogger
 	undo := zap.ReplaceGlobals(logger)
 	ZeriLogger = zap.S()
 
-	return ZeriLogger, undo
+	return func() {
+		undo()
+		ZeriLogger = prev
+	}
 }
 
 // NewLoggerWithAttributes creates a new SugaredLogger with additional attributes added from the provided map.
@@ -62,3 +170,66 @@ func NewLoggerWithAttributes(attributes Attributes) *zap.SugaredLogger {
 
 	return logger
 }
+
+// Sync flushes the global logger, including the entries buffered by asynchronous sinks.
+func Sync() error {
+	if ZeriLogger == nil {
+		return nil
+	}
+	return ZeriLogger.Sync()
+}
+
+// Close flushes the global logger and releases its outputs and background workers.
+// Entries logged after Close are written synchronously when possible.
+func Close() error {
+	return multierr.Append(Sync(), zeriResources.close())
+}
+
+// GetAsyncStats returns the counters of the asynchronous sinks of the global logger.
+func GetAsyncStats() []AsyncStats {
+	return zeriResources.asyncStats()
+}
+
+// GetSamplingStats returns the sampling and duplicate suppression counters of the global logger.
+func GetSamplingStats() SamplingStats {
+	return zeriResources.sampling.stats()
+}
+
+// GetRecentEntries returns the entries kept in the in-memory buffer of the global logger that
+// match filter, oldest first. It returns nil when Config.Recent is not set.
+func GetRecentEntries(filter RecentFilter) []RecentEntry {
+	if zeriResources.recent == nil {
+		return nil
+	}
+	return zeriResources.recent.snapshot(filter)
+}
+
+// AddHook starts a hook on the global logger while it is running.
+//
+// Parameters:
+// - hook: The Hook to add.
+//
+// Returns:
+// - A function removing the hook once its queued entries are handled.
+// - An error if the hook is invalid or the global logger is not initialized.
+//
+// Usage example:
+//
+//	remove, err := logger.AddHook(logger.Hook{
+//	    Name:      "alert",
+//	    RateLimit: 10,
+//	    Func: func(ctx context.Context, entry logger.HookEntry) error {
+//	        return notify(ctx, entry.Message)
+//	    },
+//	})
+func AddHook(hook Hook) (func(), error) {
+	if zeriResources.hooks == nil {
+		return nil, errors.New("logger is not initialized")
+	}
+	return zeriResources.hooks.add(hook)
+}
+
+// GetHookStats returns the counters of the hooks of the global logger.
+func GetHookStats() []HookStats {
+	return zeriResources.hookStats()
+}
@@ -0,0 +1,231 @@
+// Package loggertest captures the entries written through the zeri logger during a test
+// and provides helpers to query and assert on them.
+package loggertest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-metaverse/zeri/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Entry is a captured log entry along with its fields.
+type Entry = observer.LoggedEntry
+
+// Recorder holds the entries captured for a test.
+type Recorder struct {
+	logs *observer.ObservedLogs
+}
+
+var (
+	mu        sync.Mutex
+	recorders = map[testing.TB][]*Recorder{}
+)
+
+// Capture replaces the global ZeriLogger with an in-memory logger for the duration of the
+// test and restores the previous logger when the test ends. Since the global logger is shared,
+// tests using Capture must not run in parallel; use New for parallel tests.
+//
+// Parameters:
+// - t: The test the capture belongs to.
+//
+// Returns:
+// - A pointer to the Recorder holding the captured entries.
+//
+// Usage example:
+//
+//	rec := loggertest.Capture(t)
+//	connect("db1")
+//	loggertest.AssertLogged(t, logger.LevelError, "failed to connect", "host", "db1")
+func Capture(t testing.TB) *Recorder {
+	t.Helper()
+
+	log, rec := New(t)
+	t.Cleanup(logger.ReplaceGlobals(log.Desugar()))
+
+	return rec
+}
+
+// New creates a logger that records every entry in memory without touching the global logger,
+// which makes it safe to use with t.Parallel.
+//
+// Parameters:
+// - t: The test the logger belongs to.
+//
+// Returns:
+// - A SugaredLogger writing to the recorder.
+// - A pointer to the Recorder holding the captured entries.
+func New(t testing.TB) (*zap.SugaredLogger, *Recorder) {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	rec := &Recorder{logs: logs}
+
+	mu.Lock()
+	recorders[t] = append(recorders[t], rec)
+	mu.Unlock()
+
+	t.Cleanup(func() {
+		mu.Lock()
+		delete(recorders, t)
+		mu.Unlock()
+	})
+
+	return zap.New(core, zap.AddCaller()).Sugar(), rec
+}
+
+// All returns the captured entries in the order they were written.
+func (r *Recorder) All() []Entry {
+	return r.logs.All()
+}
+
+// Len returns the number of captured entries.
+func (r *Recorder) Len() int {
+	return r.logs.Len()
+}
+
+// Messages returns the messages of the captured entries.
+func (r *Recorder) Messages() []string {
+	entries := r.logs.All()
+	messages := make([]string, len(entries))
+	for i, entry := range entries {
+		messages[i] = entry.Message
+	}
+	return messages
+}
+
+// Reset discards the captured entries.
+func (r *Recorder) Reset() {
+	r.logs.TakeAll()
+}
+
+// FilterLevel returns the entries logged at exactly the given level.
+func (r *Recorder) FilterLevel(level logger.LevelType) *Recorder {
+	return &Recorder{logs: r.logs.FilterLevelExact(level.ZapLevel())}
+}
+
+// FilterMessage returns the entries whose message contains substr.
+func (r *Recorder) FilterMessage(substr string) *Recorder {
+	return &Recorder{logs: r.logs.FilterMessageSnippet(substr)}
+}
+
+// FilterField returns the entries carrying the field key with the given value. Values are
+// compared by their formatted representation, so 5 matches int, int64 and uint fields alike.
+func (r *Recorder) FilterField(key string, value any) *Recorder {
+	return &Recorder{logs: r.logs.Filter(func(entry Entry) bool {
+		return hasField(entry, key, value)
+	})}
+}
+
+// Filter returns the entries for which keep returns true.
+func (r *Recorder) Filter(keep func(Entry) bool) *Recorder {
+	return &Recorder{logs: r.logs.Filter(keep)}
+}
+
+// match returns the entries at level whose message contains msg and that carry every
+// key-value pair in keysAndValues.
+func (r *Recorder) match(level logger.LevelType, msg string, keysAndValues []any) *Recorder {
+	matched := r.FilterLevel(level).FilterMessage(msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		matched = matched.FilterField(fmt.Sprint(keysAndValues[i]), keysAndValues[i+1])
+	}
+	return matched
+}
+
+// AssertLogged reports a test failure unless an entry at level, whose message contains msg and
+// that carries every key-value pair in keysAndValues, was captured.
+func (r *Recorder) AssertLogged(t testing.TB, level logger.LevelType, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	if r.match(level, msg, keysAndValues).Len() > 0 {
+		return true
+	}
+	t.Errorf("expected %s entry %q with %v to be logged; captured:\n%s", level, msg, keysAndValues, r)
+	return false
+}
+
+// AssertNotLogged reports a test failure if an entry matching level, msg and keysAndValues
+// was captured.
+func (r *Recorder) AssertNotLogged(t testing.TB, level logger.LevelType, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	matched := r.match(level, msg, keysAndValues)
+	if matched.Len() == 0 {
+		return true
+	}
+	t.Errorf("expected no %s entry %q with %v to be logged; matched:\n%s", level, msg, keysAndValues, matched)
+	return false
+}
+
+// String formats the captured entries, one per line.
+func (r *Recorder) String() string {
+	var sb strings.Builder
+	for _, entry := range r.logs.All() {
+		fmt.Fprintf(&sb, "\t%s %q %v\n", entry.Level.CapitalString(), entry.Message, entry.ContextMap())
+	}
+	return sb.String()
+}
+
+// AssertLogged reports a test failure unless one of the recorders created for t by Capture
+// or New captured an entry at level, whose message contains msg and that carries every
+// key-value pair in keysAndValues.
+//
+// Usage example:
+//
+//	loggertest.AssertLogged(t, logger.LevelError, "failed to connect", "host", "db1")
+func AssertLogged(t testing.TB, level logger.LevelType, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	merged := recorderFor(t)
+	if merged == nil {
+		t.Errorf("loggertest: no recorder for %s; call Capture or New first", t.Name())
+		return false
+	}
+	return merged.AssertLogged(t, level, msg, keysAndValues...)
+}
+
+// AssertNotLogged reports a test failure if one of the recorders created for t captured an
+// entry matching level, msg and keysAndValues.
+func AssertNotLogged(t testing.TB, level logger.LevelType, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	merged := recorderFor(t)
+	if merged == nil {
+		t.Errorf("loggertest: no recorder for %s; call Capture or New first", t.Name())
+		return false
+	}
+	return merged.AssertNotLogged(t, level, msg, keysAndValues...)
+}
+
+// recorderFor merges the entries of every recorder created for t.
+func recorderFor(t testing.TB) *Recorder {
+	mu.Lock()
+	recs := recorders[t]
+	mu.Unlock()
+
+	if len(recs) == 0 {
+		return nil
+	}
+	if len(recs) == 1 {
+		return recs[0]
+	}
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	for _, rec := range recs {
+		for _, entry := range rec.All() {
+			_ = core.Write(entry.Entry, entry.Context)
+		}
+	}
+	return &Recorder{logs: logs}
+}
+
+// hasField reports whether entry carries key with a value formatting like value.
+func hasField(entry Entry, key string, value any) bool {
+	actual, ok := entry.ContextMap()[key]
+	return ok && fmt.Sprint(actual) == fmt.Sprint(value)
+}
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// ANSI color codes used by the pretty encoder
+const (
+	colorReset   = "\x1b[0m"
+	colorBold    = "\x1b[1m"
+	colorDim     = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+)
+
+// prettyIndent is the indentation of each nesting level.
+const prettyIndent = "    "
+
+// prettyLevelColors maps zap levels to the colors of their labels.
+var prettyLevelColors = map[zapcore.Level]string{
+	zapcore.DebugLevel:  colorMagenta,
+	zapcore.InfoLevel:   colorBlue,
+	zapcore.WarnLevel:   colorYellow,
+	zapcore.ErrorLevel:  colorRed,
+	zapcore.DPanicLevel: colorRed,
+	zapcore.PanicLevel:  colorRed,
+	zapcore.FatalLevel:  colorRed,
+}
+
+// prettyEncoder is a zapcore.Encoder for local development. It writes a header line with the
+// time, level, logger name, message and caller, followed by one aligned line per field with
+// nested objects and stack traces expanded. Colors are only used when enabled.
+type prettyEncoder struct {
+	*flatEncoder
+	color bool
+}
+
+// newPrettyEncoder creates a pretty encoder using the given encoder settings.
+func newPrettyEncoder(config zapcore.EncoderConfig, color bool) zapcore.Encoder {
+	return &prettyEncoder{flatEncoder: newFlatEncoder(&config), color: color}
+}
+
+// Clone implements zapcore.Encoder.
+func (e *prettyEncoder) Clone() zapcore.Encoder {
+	return &prettyEncoder{flatEncoder: e.clone(), color: e.color}
+}
+
+// EncodeEntry implements zapcore.Encoder.
+func (e *prettyEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	buf := logfmtPool.Get()
+	e.writeHeader(buf, ent)
+
+	widths := make(map[int]int)
+	for _, field := range enc.fields {
+		depth := len(field.path) - 1
+		widths[depth] = max(widths[depth], len(field.path[depth]))
+	}
+
+	var parent []string
+	for _, field := range enc.fields {
+		fieldParent := field.path[:len(field.path)-1]
+		common := 0
+		for common < len(parent) && common < len(fieldParent) && parent[common] == fieldParent[common] {
+			common++
+		}
+		for depth := common; depth < len(fieldParent); depth++ {
+			buf.AppendString(strings.Repeat(prettyIndent, depth+1))
+			buf.AppendString(e.paint(colorCyan, fieldParent[depth]+":"))
+			buf.AppendByte('\n')
+		}
+		parent = fieldParent
+
+		depth := len(fieldParent)
+		key := field.path[depth]
+		buf.AppendString(strings.Repeat(prettyIndent, depth+1))
+		buf.AppendString(e.paint(colorCyan, key+":"))
+		buf.AppendString(strings.Repeat(" ", widths[depth]-len(key)+1))
+		buf.AppendString(indentLines(field.value, strings.Repeat(prettyIndent, depth+2)))
+		buf.AppendByte('\n')
+	}
+
+	if ent.Stack != "" && e.config.StacktraceKey != "" {
+		buf.AppendString(prettyIndent)
+		buf.AppendString(e.paint(colorCyan, e.config.StacktraceKey+":"))
+		buf.AppendByte('\n')
+		for _, line := range strings.Split(ent.Stack, "\n") {
+			buf.AppendString(prettyIndent + prettyIndent)
+			if strings.HasPrefix(line, "\t") {
+				buf.AppendString(e.paint(colorDim, prettyIndent+strings.TrimPrefix(line, "\t")))
+			} else {
+				buf.AppendString(line)
+			}
+			buf.AppendByte('\n')
+		}
+	}
+
+	return buf, nil
+}
+
+// writeHeader writes the first line of the entry.
+func (e *prettyEncoder) writeHeader(buf *buffer.Buffer, ent zapcore.Entry) {
+	var parts []string
+	if e.config.TimeKey != "" && e.config.EncodeTime != nil {
+		parts = append(parts, e.paint(colorDim, e.primitive(func(arr zapcore.PrimitiveArrayEncoder) { e.config.EncodeTime(ent.Time, arr) })))
+	}
+	if e.config.LevelKey != "" {
+		parts = append(parts, e.paint(prettyLevelColors[ent.Level], fmt.Sprintf("%-5s", ent.Level.CapitalString())))
+	}
+	if e.config.NameKey != "" && ent.LoggerName != "" {
+		parts = append(parts, e.paint(colorCyan, "["+ent.LoggerName+"]"))
+	}
+	if e.config.MessageKey != "" {
+		parts = append(parts, e.paint(colorBold, ent.Message))
+	}
+	if e.config.CallerKey != "" && ent.Caller.Defined && e.config.EncodeCaller != nil {
+		parts = append(parts, e.paint(colorDim, e.primitive(func(arr zapcore.PrimitiveArrayEncoder) { e.config.EncodeCaller(ent.Caller, arr) })))
+	}
+
+	buf.AppendString(strings.Join(parts, " "))
+	buf.AppendByte('\n')
+}
+
+// paint wraps s in the given color when colors are enabled.
+func (e *prettyEncoder) paint(color, s string) string {
+	if !e.color || color == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// indentLines indents every line of a multi-line value but the first.
+func indentLines(value, indent string) string {
+	return strings.ReplaceAll(value, "\n", "\n"+indent)
+}
+
+// isTerminal reports whether output is stdout or stderr attached to a terminal. Colors can be
+// turned off with the NO_COLOR environment variable.
+func isTerminal(output string) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	var file *os.File
+	switch output {
+	case OutputStdout:
+		file = os.Stdout
+	case OutputStderr:
+		file = os.Stderr
+	default:
+		return false
+	}
+
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
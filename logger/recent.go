@@ -0,0 +1,412 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// Recent buffer defaults
+const (
+	defaultRecentSize      = 1000
+	recentSubscriberBuffer = 256
+	recentHeartbeat        = 15 * time.Second
+)
+
+type RecentConfig struct {
+	// Size is the number of entries kept for each level (default: 1000).
+	Size int
+
+	// PerLevel overrides Size for specific levels, such as keeping more errors than debug entries.
+	PerLevel map[LevelType]int
+
+	// Level is the minimum level kept in the buffer (default: debug).
+	Level LevelType
+}
+
+// RecentEntry is a log entry kept in the in-memory buffer.
+type RecentEntry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Logger  string         `json:"logger,omitempty"`
+	Message string         `json:"message"`
+	Caller  string         `json:"caller,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+	Stack   string         `json:"stacktrace,omitempty"`
+
+	seq   uint64
+	level zapcore.Level
+}
+
+// RecentFilter selects entries from the in-memory buffer.
+type RecentFilter struct {
+	// Level is the minimum level of the entries.
+	Level LevelType
+
+	// Logger keeps the entries of the named logger and its children.
+	Logger string
+
+	// Contains keeps the entries whose message contains the substring.
+	Contains string
+
+	// Since and Until bound the time of the entries.
+	Since, Until time.Time
+
+	// Limit keeps only the latest entries.
+	Limit int
+}
+
+// recentRing is a fixed-size ring of entries.
+type recentRing struct {
+	entries []RecentEntry
+	next    int
+	full    bool
+}
+
+// recentBuffer keeps the latest entries of each level and fans new entries out to subscribers.
+type recentBuffer struct {
+	mu          sync.RWMutex
+	rings       map[zapcore.Level]*recentRing
+	seq         uint64
+	subscribers map[chan RecentEntry]struct{}
+}
+
+// newRecentBuffer creates the rings of every level according to config.
+func newRecentBuffer(config *RecentConfig) (*recentBuffer, error) {
+	b := &recentBuffer{rings: make(map[zapcore.Level]*recentRing), subscribers: make(map[chan RecentEntry]struct{})}
+	for level := zapcore.DebugLevel; level <= zapcore.FatalLevel; level++ {
+		b.rings[level] = &recentRing{entries: make([]RecentEntry, utils.DefaultIfEmpty(config.Size, defaultRecentSize))}
+	}
+	for name, size := range config.PerLevel {
+		level, err := ParseLevel(string(name))
+		if err != nil {
+			return nil, err
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("recent buffer size of level %q must be positive", name)
+		}
+		b.rings[logLevelMap[level]] = &recentRing{entries: make([]RecentEntry, size)}
+	}
+	return b, nil
+}
+
+// add stores entry and delivers it to the subscribers, skipping those that cannot keep up.
+func (b *recentBuffer) add(entry RecentEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	entry.seq = b.seq
+
+	ring := b.rings[entry.level]
+	ring.entries[ring.next] = entry
+	ring.next = (ring.next + 1) % len(ring.entries)
+	ring.full = ring.full || ring.next == 0
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+// snapshot returns the entries matching filter, oldest first.
+func (b *recentBuffer) snapshot(filter RecentFilter) []RecentEntry {
+	b.mu.RLock()
+	var entries []RecentEntry
+	for _, ring := range b.rings {
+		count := ring.next
+		if ring.full {
+			count = len(ring.entries)
+		}
+		for i := 0; i < count; i++ {
+			if entry := ring.entries[i]; filter.match(entry) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries
+}
+
+// subscribe registers a channel receiving every new entry until unsubscribe is called.
+func (b *recentBuffer) subscribe() (chan RecentEntry, func()) {
+	ch := make(chan RecentEntry, recentSubscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+}
+
+// match reports whether entry passes the filter.
+func (f RecentFilter) match(entry RecentEntry) bool {
+	switch {
+	case f.Level != "" && entry.level < f.Level.ZapLevel():
+		return false
+	case f.Logger != "" && entry.Logger != f.Logger && !strings.HasPrefix(entry.Logger, f.Logger+"."):
+		return false
+	case f.Contains != "" && !strings.Contains(entry.Message, f.Contains):
+		return false
+	case !f.Since.IsZero() && entry.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && entry.Time.After(f.Until):
+		return false
+	}
+	return true
+}
+
+// recentCore is a zapcore.Core that records entries in a recentBuffer.
+type recentCore struct {
+	zapcore.LevelEnabler
+	buffer *recentBuffer
+	fields []zapcore.Field
+}
+
+// With implements zapcore.Core.
+func (c *recentCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...)
+	return &clone
+}
+
+// Check implements zapcore.Core.
+func (c *recentCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *recentCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	entry := RecentEntry{
+		Time:    ent.Time,
+		Level:   ent.Level.String(),
+		Logger:  ent.LoggerName,
+		Message: ent.Message,
+		Stack:   ent.Stack,
+		level:   ent.Level,
+	}
+	if ent.Caller.Defined {
+		entry.Caller = ent.Caller.TrimmedPath()
+	}
+	if len(enc.Fields) > 0 {
+		entry.Fields = enc.Fields
+	}
+
+	c.buffer.add(entry)
+	return nil
+}
+
+// Sync implements zapcore.Core.
+func (c *recentCore) Sync() error {
+	return nil
+}
+
+// RecentEntries returns the entries kept in the in-memory buffer of the logger that match
+// filter, oldest first. It returns nil when Config.Recent is not set.
+func (l *Logger) RecentEntries(filter RecentFilter) []RecentEntry {
+	if l.res.recent == nil {
+		return nil
+	}
+	return l.res.recent.snapshot(filter)
+}
+
+// RecentHandler returns an http.Handler serving the in-memory buffer of the logger.
+func (l *Logger) RecentHandler() http.Handler {
+	return &recentHandler{res: func() *resources { return l.res }}
+}
+
+// RecentHandler returns an http.Handler serving the in-memory buffer of the global logger.
+// The handler always reads the buffer of the current global logger, so it can be mounted
+// before InitLogger is called.
+//
+// Query parameters:
+// - level: The minimum level (e.g., warn).
+// - logger: The logger name; children such as "db.pool" of "db" are included.
+// - q: A substring of the message.
+// - since, until: An RFC3339 time, or a duration relative to now such as 15m.
+// - limit: The maximum number of entries, keeping the latest.
+// - format: json (default) or text.
+// - stream: When set, new entries are streamed as Server-Sent Events instead.
+//
+// Usage example:
+//
+//	http.Handle("/debug/logs", logger.RecentHandler())
+func RecentHandler() http.Handler {
+	return &recentHandler{res: func() *resources { return zeriResources }}
+}
+
+// recentHandler serves a recent buffer over HTTP.
+type recentHandler struct {
+	res func() *resources
+}
+
+// ServeHTTP implements http.Handler.
+func (h *recentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	buffer := h.res().recent
+	if buffer == nil {
+		http.Error(w, "recent log buffer is not enabled", http.StatusNotFound)
+		return
+	}
+
+	filter, err := parseRecentFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Query().Has("stream") || r.Header.Get("Accept") == "text/event-stream" {
+		streamRecent(w, r, buffer, filter)
+		return
+	}
+
+	entries := buffer.snapshot(filter)
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, entry := range entries {
+			writeRecentText(w, entry)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(utils.DefaultIfEmpty(entries, []RecentEntry{}))
+}
+
+// streamRecent sends new entries matching filter as Server-Sent Events until the client goes away.
+func streamRecent(w http.ResponseWriter, r *http.Request, buffer *recentBuffer, filter RecentFilter) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, unsubscribe := buffer.subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(recentHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			_, _ = fmt.Fprint(w, ": heartbeat\n\n")
+		case entry := <-ch:
+			if !filter.match(entry) {
+				continue
+			}
+			data, err := json.Marshal(entry)
+			if err != nil {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "id: %d\nevent: log\ndata: %s\n\n", entry.seq, data)
+		}
+		flusher.Flush()
+	}
+}
+
+// parseRecentFilter reads a RecentFilter from the query parameters of r.
+func parseRecentFilter(r *http.Request) (RecentFilter, error) {
+	query := r.URL.Query()
+	filter := RecentFilter{Logger: query.Get("logger"), Contains: query.Get("q")}
+
+	if level := query.Get("level"); level != "" {
+		parsed, err := ParseLevel(level)
+		if err != nil {
+			return filter, err
+		}
+		filter.Level = parsed
+	}
+
+	var err error
+	if filter.Since, err = parseRecentTime(query.Get("since")); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = parseRecentTime(query.Get("until")); err != nil {
+		return filter, err
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+			return filter, fmt.Errorf("invalid limit %q", limit)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseRecentTime parses an RFC3339 time or a duration relative to now.
+func parseRecentTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 or a duration", value)
+	}
+	return t, nil
+}
+
+// writeRecentText writes entry as a single line of text.
+func writeRecentText(w http.ResponseWriter, entry RecentEntry) {
+	line := fmt.Sprintf("%s %-5s", entry.Time.Format(time.RFC3339Nano), strings.ToUpper(entry.Level))
+	if entry.Logger != "" {
+		line += " [" + entry.Logger + "]"
+	}
+	line += " " + entry.Message
+
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, err := json.Marshal(entry.Fields[key])
+		if err != nil {
+			value = []byte(fmt.Sprint(entry.Fields[key]))
+		}
+		line += " " + key + "=" + string(value)
+	}
+
+	_, _ = fmt.Fprintln(w, line)
+}
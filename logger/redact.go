@@ -0,0 +1,313 @@
+package logger
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-metaverse/zeri/tag"
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redaction defaults
+const (
+	DefaultRedactMask = "[REDACTED]"
+
+	redactTagName  = "log"
+	redactTagValue = "REDACT"
+	redactMaxDepth = 32
+)
+
+// defaultRedactKeys lists the key fragments whose values are always masked.
+var defaultRedactKeys = []string{"password", "passwd", "token", "authorization", "secret"}
+
+// defaultRedactPatterns matches emails, payment card numbers and JWT-looking strings.
+var defaultRedactPatterns = []string{
+	`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`,
+	`\b(?:\d[ \-]?){12,18}\d\b`,
+	`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`,
+}
+
+type RedactConfig struct {
+	// Keys lists additional key fragments whose values are masked. Matching is case-insensitive
+	// and ignores "-" and "_", so "apikey" also masks "api_key" and "X-Api-Key".
+	Keys []string
+
+	// Patterns lists additional regular expressions whose matches are masked inside string values.
+	Patterns []string
+
+	// Mask is the replacement for redacted values (default: [REDACTED]).
+	Mask string
+
+	// DisableDefaults drops the built-in key fragments and patterns.
+	DisableDefaults bool
+}
+
+// redactor masks sensitive values by key name, by regular expression and by struct tag.
+type redactor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+	mask     string
+}
+
+// newRedactor compiles the redaction rules from the provided configuration.
+//
+// Parameters:
+// - config: A pointer to a RedactConfig struct containing the redaction rules.
+//
+// Returns:
+// - A pointer to the initialized redactor.
+// - An error if one of the patterns is not a valid regular expression.
+func newRedactor(config *RedactConfig) (*redactor, error) {
+	keys, patterns := config.Keys, config.Patterns
+	if !config.DisableDefaults {
+		keys = append(append([]string{}, defaultRedactKeys...), keys...)
+		patterns = append(append([]string{}, defaultRedactPatterns...), patterns...)
+	}
+
+	r := &redactor{mask: utils.DefaultIfEmpty(config.Mask, DefaultRedactMask)}
+	for _, key := range keys {
+		r.keys = append(r.keys, normalizeKey(key))
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// sensitiveKey reports whether values stored under key must be masked.
+func (r *redactor) sensitiveKey(key string) bool {
+	key = normalizeKey(key)
+	for _, fragment := range r.keys {
+		if fragment != "" && strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString masks every pattern match inside s.
+func (r *redactor) redactString(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, r.mask)
+	}
+	return s
+}
+
+// redactFields returns a copy of fields with sensitive values masked.
+func (r *redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		redacted[i] = r.redactField(field)
+	}
+	return redacted
+}
+
+// redactField masks a single field according to its key and type.
+func (r *redactor) redactField(field zapcore.Field) zapcore.Field {
+	if field.Type != zapcore.NamespaceType && field.Type != zapcore.SkipType && r.sensitiveKey(field.Key) {
+		return zap.String(field.Key, r.mask)
+	}
+
+	switch field.Type {
+	case zapcore.StringType:
+		field.String = r.redactString(field.String)
+	case zapcore.ByteStringType:
+		return zap.ByteString(field.Key, []byte(r.redactString(string(field.Interface.([]byte)))))
+	case zapcore.StringerType:
+		return zap.String(field.Key, r.redactString(fmt.Sprint(field.Interface)))
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			if msg := r.redactString(err.Error()); msg != err.Error() {
+				return zap.NamedError(field.Key, errors.New(msg))
+			}
+		}
+	case zapcore.ReflectType:
+		return zap.Reflect(field.Key, r.redactValue(reflect.ValueOf(field.Interface), 0))
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType, zapcore.InlineMarshalerType:
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+		if field.Type == zapcore.InlineMarshalerType {
+			return zap.Inline(redactedObject(r.redactValue(reflect.ValueOf(enc.Fields), 0).(map[string]any)))
+		}
+		return zap.Reflect(field.Key, r.redactValue(reflect.ValueOf(enc.Fields[field.Key]), 0))
+	}
+
+	return field
+}
+
+// redactValue returns a copy of v with sensitive values masked. Structs are converted to maps
+// keyed by their JSON names so that tagged fields can be dropped or masked individually.
+func (r *redactor) redactValue(v reflect.Value, depth int) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > redactMaxDepth {
+		return r.mask
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return r.redactValue(v.Elem(), depth+1)
+	case reflect.String:
+		return r.redactString(v.String())
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if r.sensitiveKey(key) {
+				out[key] = r.mask
+				continue
+			}
+			out[key] = r.redactValue(iter.Value(), depth+1)
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8) {
+			return valueInterface(v)
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = r.redactValue(v.Index(i), depth+1)
+		}
+		return out
+	case reflect.Struct:
+		if isMarshaler(v) {
+			return valueInterface(v)
+		}
+		out := make(map[string]any, v.NumField())
+		r.redactStruct(v, out, depth)
+		return out
+	default:
+		return valueInterface(v)
+	}
+}
+
+// redactStruct copies the exported fields of v into out, honoring json names and the
+// `log:"redact"` tag. Untagged embedded structs are flattened the way encoding/json does.
+func (r *redactor) redactStruct(v reflect.Value, out map[string]any, depth int) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonFieldName(field)
+		if skip || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		value := v.Field(i)
+		if _, ok := tag.ParseTag(field.Tag.Get(redactTagName), ",")[redactTagValue]; ok || r.sensitiveKey(name) {
+			out[name] = r.mask
+			continue
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			for value.Kind() == reflect.Pointer && !value.IsNil() {
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct && !isMarshaler(value) {
+				r.redactStruct(value, out, depth+1)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+		}
+
+		out[name] = r.redactValue(value, depth+1)
+	}
+}
+
+// redactCore is a zapcore.Core that masks sensitive values before they reach the wrapped core.
+type redactCore struct {
+	zapcore.Core
+	redactor *redactor
+}
+
+// newRedactCore wraps core with the given redactor.
+func newRedactCore(core zapcore.Core, r *redactor) zapcore.Core {
+	return &redactCore{Core: core, redactor: r}
+}
+
+// With implements zapcore.Core.
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactor.redactFields(fields)), redactor: c.redactor}
+}
+
+// Check implements zapcore.Core.
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.redactor.redactString(ent.Message)
+	return writeThrough(c.Core, ent, c.redactor.redactFields(fields))
+}
+
+// redactedObject re-emits an already redacted inline object.
+type redactedObject map[string]any
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o redactedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for key, value := range o {
+		if err := enc.AddReflected(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeKey lowercases key and strips the separators ignored when matching key names.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+}
+
+// jsonFieldName returns the name encoding/json uses for field and whether it is skipped.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return "", true
+	}
+	return utils.DefaultIfEmpty(name, field.Name), false
+}
+
+// isMarshaler reports whether v encodes itself, in which case it is kept as is.
+func isMarshaler(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	switch v.Interface().(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return true
+	}
+	return false
+}
+
+// valueInterface returns the value held by v, or its string form when v is unexported.
+func valueInterface(v reflect.Value) any {
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprint(v)
+}
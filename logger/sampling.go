@@ -0,0 +1,276 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// Default sampling settings, matching the zap production sampler
+const (
+	defaultSamplingInitial    = 100
+	defaultSamplingThereafter = 100
+	defaultSamplingTick       = time.Second
+	defaultDedupWindow        = 10 * time.Second
+)
+
+type SamplingConfig struct {
+	// Disabled turns sampling off, including the default sampling of production mode.
+	Disabled bool
+
+	// Initial is the number of entries with the same level and message logged per Tick (default: 100).
+	Initial int
+
+	// Thereafter logs every Thereafter-th entry once Initial is reached; 0 drops all of them,
+	// which turns sampling into a per-message rate limit.
+	Thereafter int
+
+	// Tick is the interval over which entries are counted (default: 1s).
+	Tick time.Duration
+}
+
+type DedupConfig struct {
+	// Window is the interval over which identical entries are collapsed (default: 10s).
+	Window time.Duration
+}
+
+// SamplingStats reports the counters of sampling and duplicate suppression.
+type SamplingStats struct {
+	// Sampled is the number of entries kept by the sampler.
+	Sampled uint64
+
+	// Dropped is the number of entries dropped by the sampler.
+	Dropped uint64
+
+	// Deduplicated is the number of entries collapsed into a repeat summary.
+	Deduplicated uint64
+}
+
+// samplingCounters holds the counters behind SamplingStats.
+type samplingCounters struct {
+	sampled      atomic.Uint64
+	dropped      atomic.Uint64
+	deduplicated atomic.Uint64
+}
+
+// stats returns a snapshot of the counters.
+func (c *samplingCounters) stats() SamplingStats {
+	return SamplingStats{Sampled: c.sampled.Load(), Dropped: c.dropped.Load(), Deduplicated: c.deduplicated.Load()}
+}
+
+// hook counts the decisions of the zap sampler.
+func (c *samplingCounters) hook(_ zapcore.Entry, decision zapcore.SamplingDecision) {
+	if decision&zapcore.LogDropped != 0 {
+		c.dropped.Add(1)
+	} else {
+		c.sampled.Add(1)
+	}
+}
+
+// newSamplerCore wraps core with the sampler described by config. Without a configuration,
+// production mode samples 100 entries per second and every 100th afterwards.
+//
+// Parameters:
+// - core: The zapcore.Core to sample.
+// - config: A pointer to the logger Config containing the sampling settings.
+// - counters: The counters updated with every sampling decision.
+//
+// Returns:
+// - The sampled core, or core itself when sampling is disabled.
+func newSamplerCore(core zapcore.Core, config *Config, counters *samplingCounters) zapcore.Core {
+	sampling := config.Sampling
+	if sampling == nil {
+		if config.EnableDevMode {
+			return core
+		}
+		sampling = &SamplingConfig{Initial: defaultSamplingInitial, Thereafter: defaultSamplingThereafter}
+	}
+	if sampling.Disabled {
+		return core
+	}
+
+	return zapcore.NewSamplerWithOptions(
+		core,
+		utils.DefaultIfEmpty(sampling.Tick, defaultSamplingTick),
+		utils.DefaultIfEmpty(sampling.Initial, defaultSamplingInitial),
+		sampling.Thereafter,
+		zapcore.SamplerHook(counters.hook),
+	)
+}
+
+// dedupState tracks the repeats of an entry within the current window.
+type dedupState struct {
+	core    zapcore.Core
+	ent     zapcore.Entry
+	fields  []zapcore.Field
+	repeats int
+	seen    bool
+}
+
+// deduper collapses identical entries logged within a window. It is shared by a dedup core
+// and all its children.
+type deduper struct {
+	mu      sync.Mutex
+	window  time.Duration
+	states  map[string]*dedupState
+	counter *samplingCounters
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// newDeduper creates a deduper and starts the worker emitting repeat summaries at the end
+// of every window.
+func newDeduper(config *DedupConfig, counters *samplingCounters) *deduper {
+	d := &deduper{
+		window:  utils.DefaultIfEmpty(config.Window, defaultDedupWindow),
+		states:  make(map[string]*dedupState),
+		counter: counters,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// run flushes the repeat summaries at the end of every window until the deduper is closed.
+func (d *deduper) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.flush(true)
+		case <-d.stop:
+			d.flush(false)
+			return
+		}
+	}
+}
+
+// observe records an entry and reports whether it must be written. The first occurrence
+// within a window is written; repeats are counted.
+func (d *deduper) observe(key string, core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if state, ok := d.states[key]; ok {
+		state.repeats++
+		state.seen = true
+		d.counter.deduplicated.Add(1)
+		return false
+	}
+
+	d.states[key] = &dedupState{core: core, ent: ent, fields: append([]zapcore.Field(nil), fields...), seen: true}
+	return true
+}
+
+// flush writes a summary for every entry repeated during the window. When keep is true,
+// entries seen during the window stay tracked for the next one; the others are forgotten.
+func (d *deduper) flush(keep bool) {
+	d.mu.Lock()
+	var summaries []*dedupState
+	for key, state := range d.states {
+		if state.repeats > 0 {
+			summaries = append(summaries, &dedupState{core: state.core, ent: state.ent, fields: state.fields, repeats: state.repeats})
+		}
+		if !keep || !state.seen {
+			delete(d.states, key)
+			continue
+		}
+		state.repeats, state.seen = 0, false
+	}
+	d.mu.Unlock()
+
+	for _, summary := range summaries {
+		ent := summary.ent
+		ent.Time = time.Now()
+		ent.Message = fmt.Sprintf("%s (repeated %s times in %s)", ent.Message, formatCount(summary.repeats), d.window)
+		_ = writeThrough(summary.core, ent, append(summary.fields, zapcore.Field{Key: "repeated", Type: zapcore.Int64Type, Integer: int64(summary.repeats)}))
+	}
+}
+
+// close emits the pending summaries and stops the worker.
+func (d *deduper) close() error {
+	select {
+	case <-d.stop:
+	default:
+		close(d.stop)
+	}
+	<-d.done
+	return nil
+}
+
+// dedupCore is a zapcore.Core that collapses identical entries, meaning the same level,
+// message, context and fields, into a single line followed by a repeat summary.
+type dedupCore struct {
+	zapcore.Core
+	deduper *deduper
+	context string
+}
+
+// newDedupCore wraps core with the given deduper.
+func newDedupCore(core zapcore.Core, d *deduper) zapcore.Core {
+	return &dedupCore{Core: core, deduper: d}
+}
+
+// With implements zapcore.Core.
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{Core: c.Core.With(fields), deduper: c.deduper, context: c.context + encodeFields(fields)}
+}
+
+// Check implements zapcore.Core.
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := ent.Level.String() + "\x00" + ent.LoggerName + "\x00" + ent.Message + "\x00" + c.context + encodeFields(fields)
+	if !c.deduper.observe(key, c.Core, ent, fields) {
+		return nil
+	}
+	return writeThrough(c.Core, ent, fields)
+}
+
+// Sync implements zapcore.Core. Pending repeat summaries are written before syncing.
+func (c *dedupCore) Sync() error {
+	c.deduper.flush(true)
+	return c.Core.Sync()
+}
+
+// fieldKeyEncoder encodes fields into a compact form used to compare entries.
+var fieldKeyEncoder = zapcore.NewJSONEncoder(zapcore.EncoderConfig{})
+
+// encodeFields returns a string identifying the given fields.
+func encodeFields(fields []zapcore.Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	buf, err := fieldKeyEncoder.EncodeEntry(zapcore.Entry{}, fields)
+	if err != nil {
+		return fmt.Sprint(fields)
+	}
+	defer buf.Free()
+	return buf.String()
+}
+
+// formatCount formats n with thousands separators, such as 4,812.
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
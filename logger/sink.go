@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Standard outputs
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+type SinkConfig struct {
+	// Output is the destination of the sink: stdout, stderr, a file path or a URL registered
+	// with zap.RegisterSink (default: stderr).
+	Output string
+
+	// Level defines the minimum level written to the sink (default: Config.Level).
+	Level LevelType
+
+	// Encoding specifies the format of the sink (default: Config.Encoding).
+	Encoding EncodingType
+
+	// Layout overrides Config.Layout for the sink.
+	Layout *LayoutConfig
+
+	// Keys overrides the field names used by the sink encoder, on top of the layout.
+	Keys KeyConfig
+
+	// Syslog sends the entries to a syslog daemon instead of Output.
+	Syslog *SyslogConfig
+
+	// Journald sends the entries to journald as structured fields instead of Output.
+	Journald *JournaldConfig
+
+	// Async writes the entries from a background worker through a bounded buffer (default: disabled).
+	Async *AsyncConfig
+}
+
+type KeyConfig struct {
+	// MessageKey is the field name of the log message (default: message). Any key set to OmitKey ("-")
+	// is left out of the output.
+	MessageKey string `json:"message" yaml:"message"`
+
+	// TimeKey is the field name of the timestamp (default: time).
+	TimeKey string `json:"time" yaml:"time"`
+
+	// LevelKey is the field name of the level (default: level).
+	LevelKey string `json:"level" yaml:"level"`
+
+	// NameKey is the field name of the logger name (default: log).
+	NameKey string `json:"name" yaml:"name"`
+
+	// CallerKey is the field name of the caller (default: caller).
+	CallerKey string `json:"caller" yaml:"caller"`
+
+	// StacktraceKey is the field name of the stacktrace (default: stacktrace).
+	StacktraceKey string `json:"stacktrace" yaml:"stacktrace"`
+}
+
+// newSinkCore creates the zapcore.Core writing to a single sink, wrapped in an asynchronous
+// core when the sink enables it.
+//
+// Parameters:
+// - config: A pointer to the logger Config providing the defaults of the sink.
+// - sink: The SinkConfig describing the output, level, encoding and keys of the sink.
+// - res: The resources of the logger, collecting what must be released on close.
+//
+// Returns:
+// - The zapcore.Core writing to the sink.
+// - An error if the output could not be opened or the encoding is unknown.
+func newSinkCore(config *Config, sink SinkConfig, res *resources) (zapcore.Core, error) {
+	core, err := newOutputCore(config, sink, res)
+	if err != nil || sink.Async == nil {
+		return core, err
+	}
+
+	queue, err := newAsyncQueue(utils.DefaultIfEmpty(sink.Output, OutputStderr), sink.Async, core)
+	if err != nil {
+		return nil, err
+	}
+	res.queues = append(res.queues, queue)
+	res.addCloser(queue.close)
+
+	return newAsyncCore(core, queue), nil
+}
+
+// newOutputCore creates the zapcore.Core writing to the output of a sink.
+func newOutputCore(config *Config, sink SinkConfig, res *resources) (zapcore.Core, error) {
+	zapLevel, err := getLevelByMode(config.EnableDevMode, config.Level, sink.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	level := zap.NewAtomicLevelAt(zapLevel)
+	if sink.Journald != nil {
+		core := newJournaldCore(sink.Journald, level)
+		res.addCloser(core.conn.close)
+		return core, nil
+	}
+
+	encoding, err := ParseEncoding(string(utils.DefaultIfEmpty(sink.Encoding, getEncodeByMode(config.EnableDevMode, config.Encoding))))
+	if err != nil {
+		return nil, err
+	}
+
+	encoderConfig, fields, err := newEncoderConfig(config, encoding, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder, err := newEncoder(encoding, encoderConfig, utils.DefaultIfEmpty(sink.Output, OutputStderr))
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	if sink.Syslog != nil {
+		core, err := newSyslogCore(sink.Syslog, encoder, level)
+		if err != nil {
+			return nil, err
+		}
+		res.addCloser(core.conn.close)
+		return core, nil
+	}
+
+	output, closeOutput, err := zap.Open(utils.DefaultIfEmpty(sink.Output, OutputStderr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log output %q: %w", sink.Output, err)
+	}
+	res.addCloser(func() error {
+		closeOutput()
+		return nil
+	})
+
+	return zapcore.NewCore(encoder, output, level), nil
+}
+
+// newEncoder creates the zapcore.Encoder for the given encoding. The pretty encoding is
+// colored only when the output is a terminal.
+func newEncoder(encoding EncodingType, encoderConfig zapcore.EncoderConfig, output string) (zapcore.Encoder, error) {
+	switch encoding {
+	case EncodingJSON:
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case EncodingConsole:
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case EncodingLogfmt:
+		return newLogfmtEncoder(encoderConfig), nil
+	case EncodingPretty:
+		return newPrettyEncoder(encoderConfig, isTerminal(output)), nil
+	default:
+		return nil, fmt.Errorf("unknown log encoding %q", encoding)
+	}
+}
@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SlogHandler is a slog.Handler that writes records into the core of a zap.Logger, so that
+// libraries logging through log/slog share the encoding, keys and sinks of the zeri logger.
+type SlogHandler struct {
+	core zapcore.Core
+	name string
+}
+
+// NewSlogHandler creates a slog.Handler backed by the provided zap.Logger. When logger is nil,
+// the global ZeriLogger is used and initialized with default settings if necessary.
+//
+// Parameters:
+// - logger: The zap.Logger whose core receives the records.
+//
+// Returns:
+// - A pointer to the initialized SlogHandler.
+func NewSlogHandler(logger *zap.Logger) *SlogHandler {
+	if logger == nil {
+		logger = NewLoggerWithAttributes(nil).Desugar()
+	}
+	return &SlogHandler{core: logger.Core(), name: logger.Name()}
+}
+
+// Enabled implements slog.Handler.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(slogToZapLevel(level))
+}
+
+// Handle implements slog.Handler. Trace and span identifiers carried by ctx are added to the entry.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	ent := zapcore.Entry{
+		Level:      slogToZapLevel(record.Level),
+		Time:       record.Time,
+		LoggerName: h.name,
+		Message:    record.Message,
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		ent.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	}
+
+	ce := h.core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	fields := TraceFields(ctx)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, attr)
+		return true
+	})
+	ce.Write(fields...)
+
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []zap.Field
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, attr)
+	}
+	return &SlogHandler{core: h.core.With(fields), name: h.name}
+}
+
+// WithGroup implements slog.Handler. Attributes added after the group are nested under its name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{core: h.core.With([]zap.Field{zap.Namespace(name)}), name: h.name}
+}
+
+// SetSlogDefault makes a SlogHandler backed by logger the slog default and redirects the
+// standard library log package through it.
+//
+// Parameters:
+// - logger: The zap.Logger that receives slog and log output. The global ZeriLogger is used when nil.
+//
+// Returns:
+// - A function that restores the previous slog default and log package output.
+//
+// Usage example:
+//
+//	undo := logger.SetSlogDefault(nil)
+//	defer undo()
+//
+//	slog.Info("handled by zeri", "user_id", 42)
+func SetSlogDefault(logger *zap.Logger) func() {
+	prevDefault, prevWriter, prevFlags := slog.Default(), log.Writer(), log.Flags()
+
+	slog.SetDefault(slog.New(NewSlogHandler(logger)))
+
+	return func() {
+		slog.SetDefault(prevDefault)
+		log.SetOutput(prevWriter)
+		log.SetFlags(prevFlags)
+	}
+}
+
+// appendSlogAttr converts attr into zap fields and appends them to fields.
+func appendSlogAttr(fields []zap.Field, attr slog.Attr) []zap.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	switch value := attr.Value; value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, value.Time()))
+	case slog.KindGroup:
+		group := slogGroup(value.Group())
+		if len(group) == 0 {
+			return fields
+		}
+		if attr.Key == "" {
+			return append(fields, zap.Inline(group))
+		}
+		return append(fields, zap.Object(attr.Key, group))
+	default:
+		if err, ok := value.Any().(error); ok {
+			return append(fields, zap.NamedError(attr.Key, err))
+		}
+		return append(fields, zap.Any(attr.Key, value.Any()))
+	}
+}
+
+// slogGroup marshals the attributes of a slog group as a nested object.
+type slogGroup []slog.Attr
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range g {
+		for _, field := range appendSlogAttr(nil, attr) {
+			field.AddTo(enc)
+		}
+	}
+	return nil
+}
+
+// slogToZapLevel maps a slog level onto the closest zap level.
+func slogToZapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
@@ -0,0 +1,251 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap/zapcore"
+)
+
+// Syslog message formats
+const (
+	SyslogRFC5424 SyslogFormat = "rfc5424"
+	SyslogRFC3164 SyslogFormat = "rfc3164"
+)
+
+// Syslog facilities
+const (
+	FacilityUser   = 1
+	FacilityDaemon = 3
+	FacilityLocal0 = 16
+	FacilityLocal7 = 23
+)
+
+// Syslog severities
+const (
+	severityEmergency = 0
+	severityAlert     = 1
+	severityCritical  = 2
+	severityError     = 3
+	severityWarning   = 4
+	severityInfo      = 6
+	severityDebug     = 7
+)
+
+type SyslogFormat string
+
+type SyslogConfig struct {
+	// Network is the transport used to reach the daemon: udp, tcp, unix or unixgram (default: unixgram).
+	// Stream transports (tcp, unix) use octet-counting framing as described in RFC 6587.
+	Network string
+
+	// Address is the daemon address, a host:port pair or a socket path (default: /dev/log).
+	Address string
+
+	// Format specifies the message format (default: rfc5424, accepts: SyslogRFC5424, SyslogRFC3164).
+	Format SyslogFormat
+
+	// Facility is the syslog facility of the messages (default: FacilityUser).
+	Facility int
+
+	// AppName identifies the application in the message header (default: the executable name).
+	AppName string
+
+	// Hostname identifies the host in the message header (default: os.Hostname).
+	Hostname string
+
+	// Reconnect controls how broken connections are re-established.
+	Reconnect ReconnectConfig
+}
+
+type ReconnectConfig struct {
+	// MaxRetries is the number of reconnect attempts for a single message (default: 3).
+	MaxRetries int
+
+	// Backoff is the delay before the first reconnect attempt; it doubles on every attempt (default: 100ms).
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay between reconnect attempts (default: 5s).
+	MaxBackoff time.Duration
+}
+
+// syslogSeverityMap maps zap levels to syslog severities.
+var syslogSeverityMap = map[zapcore.Level]int{
+	zapcore.DebugLevel:  severityDebug,
+	zapcore.InfoLevel:   severityInfo,
+	zapcore.WarnLevel:   severityWarning,
+	zapcore.ErrorLevel:  severityError,
+	zapcore.DPanicLevel: severityCritical,
+	zapcore.PanicLevel:  severityAlert,
+	zapcore.FatalLevel:  severityEmergency,
+}
+
+// syslogCore is a zapcore.Core that sends each entry to a syslog daemon with a header
+// carrying the severity of the entry.
+type syslogCore struct {
+	zapcore.LevelEnabler
+	encoder  zapcore.Encoder
+	conn     *reconnectingConn
+	format   SyslogFormat
+	facility int
+	appName  string
+	hostname string
+	pid      int
+}
+
+// newSyslogCore creates a zapcore.Core writing to the syslog daemon described by config.
+// The entry itself is encoded with encoder and sent as the syslog message body.
+//
+// Parameters:
+// - config: A pointer to a SyslogConfig struct describing the daemon and message format.
+// - encoder: The zapcore.Encoder used for the message body.
+// - level: The zapcore.LevelEnabler deciding which entries are sent.
+//
+// Returns:
+// - The zapcore.Core writing to the daemon.
+// - An error if the network or format is not supported.
+func newSyslogCore(config *SyslogConfig, encoder zapcore.Encoder, level zapcore.LevelEnabler) (*syslogCore, error) {
+	network := utils.DefaultIfEmpty(config.Network, "unixgram")
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", network)
+	}
+
+	format := utils.DefaultIfEmpty(config.Format, SyslogRFC5424)
+	if format != SyslogRFC5424 && format != SyslogRFC3164 {
+		return nil, fmt.Errorf("unsupported syslog format %q", format)
+	}
+
+	hostname := config.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	return &syslogCore{
+		LevelEnabler: level,
+		encoder:      encoder,
+		conn:         newReconnectingConn(network, utils.DefaultIfEmpty(config.Address, "/dev/log"), config.Reconnect),
+		format:       format,
+		facility:     utils.DefaultIfEmpty(config.Facility, FacilityUser),
+		appName:      utils.DefaultIfEmpty(config.AppName, filepath.Base(os.Args[0])),
+		hostname:     utils.DefaultIfEmpty(hostname, "-"),
+		pid:          os.Getpid(),
+	}, nil
+}
+
+// With implements zapcore.Core.
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.encoder = c.encoder.Clone()
+	for _, field := range fields {
+		field.AddTo(clone.encoder)
+	}
+	return &clone
+}
+
+// Check implements zapcore.Core.
+func (c *syslogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core.
+func (c *syslogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	body := strings.TrimRight(buf.String(), "\n")
+	return c.conn.write([]byte(c.header(ent) + body))
+}
+
+// Sync implements zapcore.Core.
+func (c *syslogCore) Sync() error {
+	return nil
+}
+
+// header formats the syslog header of the entry according to the configured format.
+func (c *syslogCore) header(ent zapcore.Entry) string {
+	priority := c.facility*8 + syslogSeverityMap[ent.Level]
+	if c.format == SyslogRFC3164 {
+		return fmt.Sprintf("<%d>%s %s %s[%d]: ", priority, ent.Time.Format(time.Stamp), c.hostname, c.appName, c.pid)
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s - ", priority, ent.Time.Format(time.RFC3339Nano),
+		c.hostname, c.appName, c.pid, utils.DefaultIfEmpty(ent.LoggerName, "-"))
+}
+
+// reconnectingConn is a connection to a local daemon that is dialed lazily and re-established
+// with exponential backoff when a write fails.
+type reconnectingConn struct {
+	mu        sync.Mutex
+	network   string
+	address   string
+	reconnect ReconnectConfig
+	conn      net.Conn
+}
+
+// newReconnectingConn creates a reconnectingConn, filling in the reconnect defaults.
+func newReconnectingConn(network, address string, reconnect ReconnectConfig) *reconnectingConn {
+	reconnect.MaxRetries = utils.DefaultIfEmpty(reconnect.MaxRetries, 3)
+	reconnect.Backoff = utils.DefaultIfEmpty(reconnect.Backoff, 100*time.Millisecond)
+	reconnect.MaxBackoff = utils.DefaultIfEmpty(reconnect.MaxBackoff, 5*time.Second)
+	return &reconnectingConn{network: network, address: address, reconnect: reconnect}
+}
+
+// write sends msg as a single message, framing it with its length on stream transports.
+func (c *reconnectingConn) write(msg []byte) error {
+	if c.network != "udp" && c.network != "udp4" && c.network != "udp6" && c.network != "unixgram" {
+		msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	backoff := c.reconnect.Backoff
+	var err error
+	for attempt := 0; attempt <= c.reconnect.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff = min(backoff*2, c.reconnect.MaxBackoff)
+		}
+
+		if c.conn == nil {
+			if c.conn, err = net.Dial(c.network, c.address); err != nil {
+				c.conn = nil
+				continue
+			}
+		}
+
+		if _, err = c.conn.Write(msg); err == nil {
+			return nil
+		}
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+
+	return fmt.Errorf("failed to write to %s %s: %w", c.network, c.address, err)
+}
+
+// close closes the current connection, if any.
+func (c *reconnectingConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
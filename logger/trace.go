@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-metaverse/zeri/utils"
+	"go.uber.org/zap"
+)
+
+// Trace header and default field names
+const (
+	TraceparentHeader = "traceparent"
+
+	DefaultTraceIDKey = "trace_id"
+	DefaultSpanIDKey  = "span_id"
+)
+
+// TraceContext holds the identifiers carried by a W3C traceparent header.
+type TraceContext struct {
+	// TraceID is the 32 character lowercase hex identifier of the whole trace.
+	TraceID string
+
+	// SpanID is the 16 character lowercase hex identifier of the current span.
+	SpanID string
+
+	// Flags holds the trace flags, where bit 0 marks the trace as sampled.
+	Flags byte
+}
+
+// SpanContextExtractor reads the trace and span identifiers from a context populated by a
+// tracing SDK such as OpenTelemetry. It reports false when the context carries no valid span.
+type SpanContextExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+type traceContextKey struct{}
+
+var (
+	traceIDKey = DefaultTraceIDKey
+	spanIDKey  = DefaultSpanIDKey
+
+	extractorMu   sync.RWMutex
+	spanExtractor SpanContextExtractor
+)
+
+// ParseTraceparent parses a W3C traceparent header value of the form
+// "version-traceid-spanid-flags".
+//
+// Parameters:
+// - header: The raw traceparent header value.
+//
+// Returns:
+// - The parsed TraceContext.
+// - An error if the value is malformed or carries all-zero identifiers.
+func ParseTraceparent(header string) (TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", header)
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return TraceContext{}, fmt.Errorf("invalid traceparent version %q", version)
+	}
+	if !isHex(traceID, 32) || strings.Trim(traceID, "0") == "" {
+		return TraceContext{}, fmt.Errorf("invalid trace id %q", traceID)
+	}
+	if !isHex(spanID, 16) || strings.Trim(spanID, "0") == "" {
+		return TraceContext{}, fmt.Errorf("invalid span id %q", spanID)
+	}
+	if !isHex(flags, 2) {
+		return TraceContext{}, fmt.Errorf("invalid trace flags %q", flags)
+	}
+
+	flagBytes, _ := hex.DecodeString(flags)
+	return TraceContext{TraceID: traceID, SpanID: spanID, Flags: flagBytes[0]}, nil
+}
+
+// NewTraceContext starts a new sampled trace with random trace and span identifiers.
+func NewTraceContext() TraceContext {
+	return TraceContext{TraceID: randomHex(16), SpanID: randomHex(8), Flags: 0x01}
+}
+
+// NewChild returns a TraceContext for a child span of the same trace.
+func (tc TraceContext) NewChild() TraceContext {
+	return TraceContext{TraceID: tc.TraceID, SpanID: randomHex(8), Flags: tc.Flags}
+}
+
+// IsValid reports whether both identifiers are present.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID != "" && tc.SpanID != ""
+}
+
+// Sampled reports whether the sampled flag is set.
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&0x01 == 0x01
+}
+
+// Traceparent formats the TraceContext as a version 00 traceparent header value.
+func (tc TraceContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", tc.TraceID, tc.SpanID, tc.Flags)
+}
+
+// ContextWithTrace returns a copy of ctx carrying the given TraceContext.
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceFromContext returns the TraceContext carried by ctx. A TraceContext stored with
+// ContextWithTrace takes precedence over the span found by the registered SpanContextExtractor.
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	if tc, ok := ctx.Value(traceContextKey{}).(TraceContext); ok && tc.IsValid() {
+		return tc, true
+	}
+
+	extractorMu.RLock()
+	extract := spanExtractor
+	extractorMu.RUnlock()
+
+	if extract != nil {
+		if traceID, spanID, ok := extract(ctx); ok {
+			return TraceContext{TraceID: traceID, SpanID: spanID}, true
+		}
+	}
+	return TraceContext{}, false
+}
+
+// SetSpanContextExtractor registers the function used to read span identifiers placed in the
+// context by a tracing SDK. Passing nil removes the extractor.
+//
+// Usage example with OpenTelemetry:
+//
+//	logger.SetSpanContextExtractor(func(ctx context.Context) (string, string, bool) {
+//	    sc := trace.SpanContextFromContext(ctx)
+//	    return sc.TraceID().String(), sc.SpanID().String(), sc.IsValid()
+//	})
+func SetSpanContextExtractor(fn SpanContextExtractor) {
+	extractorMu.Lock()
+	defer extractorMu.Unlock()
+	spanExtractor = fn
+}
+
+// ExtractTraceparent reads and parses the traceparent header from h.
+func ExtractTraceparent(h http.Header) (TraceContext, bool) {
+	tc, err := ParseTraceparent(h.Get(TraceparentHeader))
+	if err != nil {
+		return TraceContext{}, false
+	}
+	return tc, true
+}
+
+// InjectTraceparent writes tc into h as a traceparent header.
+func InjectTraceparent(h http.Header, tc TraceContext) {
+	if tc.IsValid() {
+		h.Set(TraceparentHeader, tc.Traceparent())
+	}
+}
+
+// TraceFields returns the trace and span fields for the trace carried by ctx, using the
+// field names configured in Config.TraceIDKey and Config.SpanIDKey.
+func TraceFields(ctx context.Context) []zap.Field {
+	tc, ok := TraceFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return []zap.Field{zap.String(traceIDKey, tc.TraceID), zap.String(spanIDKey, tc.SpanID)}
+}
+
+// WithContext returns the global ZeriLogger enriched with the trace and span identifiers
+// carried by ctx. When ctx carries no trace, the global logger is returned unchanged.
+//
+// Usage example:
+//
+//	logger.WithContext(r.Context()).Info("order created")
+func WithContext(ctx context.Context) *zap.SugaredLogger {
+	return withTrace(NewLoggerWithAttributes(nil), ctx)
+}
+
+// withTrace adds the trace fields of ctx to the given logger.
+func withTrace(logger *zap.SugaredLogger, ctx context.Context) *zap.SugaredLogger {
+	fields := TraceFields(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.Desugar().With(fields...).Sugar()
+}
+
+// TraceMiddleware extracts the incoming traceparent header, or starts a new trace when the
+// header is missing or invalid, and stores a child span in the request context. The child span
+// is echoed back in the response traceparent header.
+func TraceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc, ok := ExtractTraceparent(r.Header)
+		if ok {
+			tc = tc.NewChild()
+		} else {
+			tc = NewTraceContext()
+		}
+
+		InjectTraceparent(w.Header(), tc)
+		next.ServeHTTP(w, r.WithContext(ContextWithTrace(r.Context(), tc)))
+	})
+}
+
+// TraceTransport is an http.RoundTripper that propagates the trace carried by the request
+// context to outgoing requests as a traceparent header.
+type TraceTransport struct {
+	// Base is the underlying RoundTripper. http.DefaultTransport is used when nil.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TraceTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	tc, ok := TraceFromContext(r.Context())
+	if !ok || r.Header.Get(TraceparentHeader) != "" {
+		return base.RoundTrip(r)
+	}
+
+	r = r.Clone(r.Context())
+	InjectTraceparent(r.Header, tc.NewChild())
+	return base.RoundTrip(r)
+}
+
+// setTraceKeys applies the trace field names from the configuration.
+func setTraceKeys(config *Config) {
+	traceIDKey = utils.DefaultIfEmpty(config.TraceIDKey, DefaultTraceIDKey)
+	spanIDKey = utils.DefaultIfEmpty(config.SpanIDKey, DefaultSpanIDKey)
+}
+
+// isHex reports whether s is a lowercase hex string of length n.
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// randomHex returns n random bytes encoded as lowercase hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
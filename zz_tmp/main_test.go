@@ -0,0 +1,51 @@
+package zz
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-metaverse/zeri/logger"
+)
+
+func TestHooks(t *testing.T) {
+	var n, slow atomic.Int32
+	l, err := logger.NewLogger(&logger.Config{Sinks: []logger.SinkConfig{{Output: "/dev/null"}}, Hooks: []logger.Hook{
+		{Name: "count", RateLimit: 3, Func: func(ctx context.Context, e logger.HookEntry) error { n.Add(1); return nil }},
+		{Name: "panic", Filter: func(e logger.HookEntry) bool { return e.Fields["k"] == int64(1) }, Func: func(ctx context.Context, e logger.HookEntry) error { panic("x") }},
+		{Name: "fail", Level: "warn", Func: func(ctx context.Context, e logger.HookEntry) error { return errors.New("nope") }},
+		{Name: "slow", QueueSize: 1, Func: func(ctx context.Context, e logger.HookEntry) error { slow.Add(1); time.Sleep(50 * time.Millisecond); return nil }},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		l.With("k", i).Error("e")
+	}
+	l.Info("ignored")
+	t.Log("logging took", time.Since(start))
+	rm, _ := l.AddHook(logger.Hook{Name: "late", Func: func(ctx context.Context, e logger.HookEntry) error { t.Log("late", e.Message, e.Fields); return nil }})
+	l.Errorw("after", "a", 1)
+	time.Sleep(100 * time.Millisecond)
+	rm()
+	l.Close()
+	t.Logf("%d %d %+v", n.Load(), slow.Load(), l.HookStats())
+}
+
+func TestFatal(t *testing.T) {
+	if os.Getenv("FATAL") == "1" {
+		logger.InitLogger(&logger.Config{Hooks: []logger.Hook{logger.GoroutineDumpHook(os.Stdout)}})
+		logger.ZeriLogger.Fatal("bye")
+	}
+	out, _ := exec.Command(os.Args[0], "-test.run=TestFatal").CombinedOutput()
+	_ = out
+	cmd := exec.Command(os.Args[0], "-test.run=TestFatal")
+	cmd.Env = append(os.Environ(), "FATAL=1")
+	out, err := cmd.CombinedOutput()
+	t.Logf("%v\n%.600s", err, out)
+}